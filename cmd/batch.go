@@ -0,0 +1,311 @@
+package cmd
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/686f6c61/pingbar/internal/api"
+	"github.com/686f6c61/pingbar/internal/output"
+	"github.com/686f6c61/pingbar/internal/schedule"
+	"github.com/spf13/cobra"
+)
+
+var (
+	batchFile    string
+	batchWorkers int
+	batchRate    float64
+)
+
+// batchCmd comprueba una lista de negocios
+var batchCmd = &cobra.Command{
+	Use:   "batch [-f archivo]",
+	Short: "Comprobar una lista de negocios",
+	Long: `Lee una lista de negocios de un archivo, o de la entrada estándar si no
+se indica ninguno o es "-", y muestra el estado del primer resultado de cada
+uno en una tabla, seguida de un resumen.
+
+Cada línea es negocio,ciudad[,momento] en formato CSV. Sin ciudad se usa la
+ciudad por defecto; el momento admite lo mismo que --at, y sin él se usa --at
+o la hora actual. Las líneas vacías y las que empiezan por # se ignoran, igual
+que una cabecera "negocio,ciudad". --week, --tomorrow y --time no se admiten.
+
+Las búsquedas se hacen a la vez (--workers) sin superar --rate búsquedas por
+segundo. Con --json o --format ndjson, se imprime una línea JSON por negocio
+en el orden de la lista y una última línea con el resumen.
+
+Termina con código 0 si todas las búsquedas terminan, aunque haya negocios
+cerrados o sin horario, y con el código del error en caso contrario.
+
+Ejemplos:
+  pingbar batch -f proveedores.csv
+  printf 'farmacia,madrid\nbar pepe,sevilla,sábado 14:00\n' | pingbar batch
+  pingbar batch -f proveedores.csv --json | jq -c 'select(.result.open)'`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		runBatch()
+	},
+}
+
+func init() {
+	batchCmd.Flags().StringVarP(&batchFile, "file", "f", "", "Archivo con la lista de negocios (por defecto, la entrada estándar)")
+	batchCmd.Flags().IntVar(&batchWorkers, "workers", 4, "Búsquedas simultáneas")
+	batchCmd.Flags().Float64Var(&batchRate, "rate", 2, "Máximo de búsquedas por segundo (0 = sin límite)")
+}
+
+// batchEntry es una línea de la lista de negocios
+type batchEntry struct {
+	line     int
+	business string
+	city     string
+	at       string
+}
+
+// runBatch comprueba todos los negocios de la lista
+func runBatch() {
+	if batchWorkers < 1 {
+		usageError(fmt.Errorf("número de búsquedas simultáneas no válido: %d (mínimo 1)", batchWorkers))
+	}
+	if !(batchRate >= 0) {
+		usageError(fmt.Errorf("límite de búsquedas por segundo no válido: %g", batchRate))
+	}
+	// batch solo muestra el estado de cada negocio en un momento
+	for _, flag := range []struct {
+		name string
+		set  bool
+	}{{"--week", showWeek}, {"--tomorrow", showTomorrow}, {"--time", timeFlag != ""}} {
+		if flag.set {
+			usageError(fmt.Errorf("%s no está disponible en batch (usa --at o el momento de cada línea)", flag.name))
+		}
+	}
+
+	in := io.Reader(os.Stdin)
+	if batchFile != "" && batchFile != "-" {
+		file, err := os.Open(batchFile)
+		if err != nil {
+			usageError(err)
+		}
+		defer file.Close()
+		in = file
+	}
+
+	s := newSearcher()
+	if s.formatter.Format != "text" && !s.formatter.JSONLines() {
+		usageError(fmt.Errorf("formato no disponible en batch: %s (usa text, json o ndjson)", s.formatter.Format))
+	}
+
+	entries, err := parseBatch(in, s.cfg.DefaultCity)
+	if err != nil {
+		usageError(err)
+	}
+
+	// Validar los momentos antes de gastar créditos en las búsquedas. --at
+	// vale para las líneas sin momento y se interpreta en la zona de cada una.
+	results := make([]output.BatchResult, len(entries))
+	for i, e := range entries {
+		results[i] = output.BatchResult{Business: e.business, City: e.city}
+		when := e.at
+		if when == "" {
+			when = atFlag
+		}
+		if when == "" {
+			continue
+		}
+		at, err := schedule.ParseAt(when, time.Now().In(s.location(e.business, e.city)))
+		if err != nil {
+			if e.at == "" {
+				usageError(err)
+			}
+			usageError(fmt.Errorf("línea %d: %v", e.line, err))
+		}
+		results[i].At = at
+	}
+
+	// Ctrl-C cancela las búsquedas pendientes
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// Un error de la API key o de cuota haría fallar todas las búsquedas
+	var (
+		fatalMu sync.Mutex
+		fatal   error
+	)
+
+	// Un límite tan alto que no deja ni un nanosegundo entre búsquedas es
+	// como no tenerlo (y time.NewTicker no admite un intervalo nulo)
+	var limiter <-chan time.Time
+	if interval := batchInterval(batchRate); interval > 0 {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		limiter = ticker.C
+	}
+
+	done := make([]chan struct{}, len(results))
+	for i := range done {
+		done[i] = make(chan struct{})
+	}
+
+	jobs := make(chan int)
+	workers := batchWorkers
+	if workers > len(results) {
+		workers = len(results)
+	}
+	for w := 0; w < workers; w++ {
+		go func() {
+			for i := range jobs {
+				results[i].Result, results[i].Err = batchSearch(ctx, s, results[i], limiter)
+				if isFatal(results[i].Err) {
+					fatalMu.Lock()
+					if fatal == nil {
+						fatal = results[i].Err
+					}
+					fatalMu.Unlock()
+					cancel()
+				}
+				close(done[i])
+			}
+		}()
+	}
+	go func() {
+		for i := range results {
+			jobs <- i
+		}
+		close(jobs)
+	}()
+
+	// Los resultados se muestran en el orden de la lista
+	var summary output.BatchSummary
+	var firstErr error
+	for i := range results {
+		<-done[i]
+		summary.Add(results[i])
+		if firstErr == nil {
+			firstErr = results[i].Err
+		}
+		if s.formatter.JSONLines() && !quietFlag {
+			s.formatter.PrintBatchLine(results[i])
+		}
+	}
+
+	if !quietFlag {
+		if !s.formatter.JSONLines() {
+			s.formatter.PrintBatchTable(results)
+		}
+		s.formatter.PrintBatchSummary(summary)
+	}
+
+	if fatal != nil {
+		fail(fatal, s.lang)
+	}
+	if firstErr != nil {
+		os.Exit(exitCode(firstErr))
+	}
+}
+
+// batchInterval devuelve la espera entre búsquedas para rate búsquedas por
+// segundo, o 0 si no hay límite
+func batchInterval(rate float64) time.Duration {
+	if rate <= 0 {
+		return 0
+	}
+	interval := float64(time.Second) / rate
+	switch {
+	case interval < 1:
+		return 0
+	case interval >= math.MaxInt64:
+		return math.MaxInt64
+	}
+	return time.Duration(interval)
+}
+
+// batchSearch busca el primer resultado de una línea, esperando su turno en
+// limiter. Devuelve nil si la búsqueda no encuentra nada.
+func batchSearch(ctx context.Context, s *searcher, r output.BatchResult, limiter <-chan time.Time) (*api.BusinessInfo, error) {
+	if limiter != nil {
+		select {
+		case <-limiter:
+		case <-ctx.Done():
+		}
+	}
+	// Sin esto, una búsqueda cancelada podría responderse desde la caché
+	if ctx.Err() != nil {
+		return nil, &api.APIError{Type: api.ErrorCancelled, Message: "Búsqueda cancelada"}
+	}
+
+	opts := s.opts
+	opts.At = r.At
+	results, err := s.searchWith(ctx, r.Business, r.City, 1, opts)
+	if err != nil || len(results) == 0 {
+		return nil, err
+	}
+	return &results[0], nil
+}
+
+// isFatal indica si un error hará fallar también el resto de búsquedas
+func isFatal(err error) bool {
+	return errors.Is(err, api.ErrNoAPIKey) || errors.Is(err, api.ErrInvalidKey) || errors.Is(err, api.ErrQuota)
+}
+
+// parseBatch lee la lista de negocios en CSV: negocio,ciudad[,momento]
+func parseBatch(in io.Reader, defaultCity string) ([]batchEntry, error) {
+	reader := csv.NewReader(in)
+	reader.Comment = '#'
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	var entries []batchEntry
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("lista de negocios no válida: %v", err)
+		}
+		line, _ := reader.FieldPos(0)
+
+		for i := range record {
+			record[i] = strings.TrimSpace(record[i])
+		}
+		if len(record) == 1 && record[0] == "" {
+			continue
+		}
+		// Cabecera opcional
+		if len(entries) == 0 && (strings.EqualFold(record[0], "negocio") || strings.EqualFold(record[0], "business")) {
+			continue
+		}
+		if len(record) > 3 {
+			return nil, fmt.Errorf("línea %d: demasiados campos (usa negocio,ciudad[,momento])", line)
+		}
+
+		entry := batchEntry{line: line, business: record[0], city: defaultCity}
+		if len(record) > 1 && record[1] != "" {
+			entry.city = record[1]
+		}
+		if len(record) > 2 {
+			entry.at = record[2]
+		}
+		if entry.business == "" {
+			return nil, fmt.Errorf("línea %d: falta el negocio", line)
+		}
+		if entry.city == "" {
+			return nil, fmt.Errorf("línea %d: falta la ciudad (o configura una ciudad por defecto)", line)
+		}
+		entries = append(entries, entry)
+	}
+
+	if len(entries) == 0 {
+		return nil, errors.New("la lista de negocios está vacía")
+	}
+	return entries, nil
+}
@@ -0,0 +1,132 @@
+package cmd
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseBatch(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		defaultCity string
+		want        []batchEntry
+		wantErr     string
+	}{
+		{
+			name:  "negocio y ciudad",
+			input: "farmacia,madrid\nbar pepe,sevilla\n",
+			want: []batchEntry{
+				{line: 1, business: "farmacia", city: "madrid"},
+				{line: 2, business: "bar pepe", city: "sevilla"},
+			},
+		},
+		{
+			name:  "momento y comillas",
+			input: `"bar, tapas",madrid,sábado 14:00` + "\n",
+			want:  []batchEntry{{line: 1, business: "bar, tapas", city: "madrid", at: "sábado 14:00"}},
+		},
+		{
+			name:  "cabecera, comentarios y líneas vacías",
+			input: "negocio,ciudad\n# proveedores\n\nfarmacia, madrid \n  \n#bar,sevilla\n",
+			want:  []batchEntry{{line: 4, business: "farmacia", city: "madrid"}},
+		},
+		{
+			name:  "cabecera en inglés",
+			input: "Business,City,At\nfarmacia,madrid\n",
+			want:  []batchEntry{{line: 2, business: "farmacia", city: "madrid"}},
+		},
+		{
+			name:  "la cabecera solo cuenta en la primera línea",
+			input: "farmacia,madrid\nnegocio,sevilla\n",
+			want: []batchEntry{
+				{line: 1, business: "farmacia", city: "madrid"},
+				{line: 2, business: "negocio", city: "sevilla"},
+			},
+		},
+		{
+			name:        "ciudad por defecto",
+			input:       "farmacia\nbar pepe,\nbar luis,,mañana 13:00\n",
+			defaultCity: "bilbao",
+			want: []batchEntry{
+				{line: 1, business: "farmacia", city: "bilbao"},
+				{line: 2, business: "bar pepe", city: "bilbao"},
+				{line: 3, business: "bar luis", city: "bilbao", at: "mañana 13:00"},
+			},
+		},
+		{
+			name:    "sin ciudad ni ciudad por defecto",
+			input:   "farmacia,madrid\nbar pepe\n",
+			wantErr: "línea 2: falta la ciudad",
+		},
+		{
+			name:    "demasiados campos",
+			input:   "farmacia,madrid,sábado 14:00,extra\n",
+			wantErr: "línea 1: demasiados campos",
+		},
+		{
+			name:    "sin negocio",
+			input:   ",madrid\n",
+			wantErr: "línea 1: falta el negocio",
+		},
+		{
+			name:    "comillas sin cerrar",
+			input:   "\"farmacia,madrid\n",
+			wantErr: "lista de negocios no válida",
+		},
+		{
+			name:    "solo cabecera y comentarios",
+			input:   "negocio,ciudad\n# nada\n",
+			wantErr: "la lista de negocios está vacía",
+		},
+		{
+			name:    "vacía",
+			input:   "",
+			wantErr: "la lista de negocios está vacía",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := parseBatch(strings.NewReader(tc.input), tc.defaultCity)
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("err = %v, want %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != len(tc.want) {
+				t.Fatalf("parseBatch = %+v, want %+v", got, tc.want)
+			}
+			for i := range tc.want {
+				if got[i] != tc.want[i] {
+					t.Errorf("entrada %d = %+v, want %+v", i, got[i], tc.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestBatchInterval(t *testing.T) {
+	tests := []struct {
+		rate float64
+		want time.Duration
+	}{
+		{0, 0},
+		{2, 500 * time.Millisecond},
+		{0.5, 2 * time.Second},
+		{1e9, time.Nanosecond},
+		{2e9, 0}, // Menos de un nanosegundo: sin límite
+		{math.Inf(1), 0},
+		{1e-12, math.MaxInt64},
+	}
+	for _, tc := range tests {
+		if got := batchInterval(tc.rate); got != tc.want {
+			t.Errorf("batchInterval(%g) = %v, want %v", tc.rate, got, tc.want)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"fmt"
+	"os"
 
 	"github.com/686f6c61/pingbar/internal/cache"
 	"github.com/686f6c61/pingbar/internal/config"
@@ -44,12 +45,21 @@ var cacheInfoCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		size := cache.Size()
 		cacheDir := config.CacheDir()
+		stats, err := cache.GetStats()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error al leer las estadísticas de la caché: %v\n", err)
+		}
 
 		fmt.Println("Información de caché:")
 		fmt.Println()
 		fmt.Printf("  Directorio: %s\n", cacheDir)
 		fmt.Printf("  Entradas:   %d\n", size)
 		fmt.Printf("  TTL:        %d horas\n", cache.DefaultTTL)
+		fmt.Printf("  Aciertos:   %d\n", stats.Hits)
+		fmt.Printf("  Fallos:     %d\n", stats.Misses)
+		if total := stats.Hits + stats.Misses; total > 0 {
+			fmt.Printf("  Tasa:       %.0f%%\n", float64(stats.Hits)*100/float64(total))
+		}
 	},
 }
 
@@ -2,7 +2,9 @@ package cmd
 
 import (
 	"fmt"
+	"strings"
 
+	"github.com/686f6c61/pingbar/internal/api"
 	"github.com/686f6c61/pingbar/internal/config"
 	"github.com/686f6c61/pingbar/internal/i18n"
 	"github.com/spf13/cobra"
@@ -22,11 +24,24 @@ var configSetCmd = &cobra.Command{
 	Long: `Establecer un valor de configuración.
 
 Claves disponibles:
-  apikey        - API Key de Serper.dev (obligatorio)
-  lang          - Idioma de salida (es/en)
-  default-city  - Ciudad por defecto para búsquedas
-  color         - Colores en terminal (on/off/auto)
-  default-limit - Número de resultados por defecto (1-50)
+  apikey            - API Key de Serper.dev (obligatorio)
+  lang              - Idioma de salida (es/en)
+  default-city      - Ciudad por defecto para búsquedas
+  color             - Colores en terminal (on/off/auto)
+  default-limit     - Número de resultados por defecto (1-50)
+  provider          - Proveedor de búsqueda (serper/osm)
+  overpass-url      - Servidor Overpass para el proveedor osm
+  country           - País de búsqueda (es, pt, fr...)
+  search-lang       - Idioma de búsqueda (por defecto, el del país)
+  hours-lookups     - Horarios consultados a la API por búsqueda (1-50)
+  hours-concurrency - Consultas de horario simultáneas (1-10)
+  api-base-url      - URL base de la API de Serper (o $PINGBAR_API_BASE_URL)
+  retries           - Reintentos ante errores 429/5xx o de red (0-10)
+  retry-max-wait    - Espera máxima entre reintentos (por ejemplo 10s)
+  notify-command    - Comando que ejecuta pingbar notify cuando el negocio abre
+  notify-fifo       - FIFO o archivo donde pingbar notify escribe el aviso
+  notify-webhook    - URL a la que pingbar notify envía el aviso (POST JSON)
+  notify-dbus       - Notificación de escritorio por D-Bus (on/off/auto)
 
 Ejemplos:
   pingbar config set apikey XXXXXXXXXXXXXXXXXXXX
@@ -37,6 +52,12 @@ Ejemplos:
 		key := args[0]
 		value := args[1]
 
+		// Los proveedores se registran en el paquete api
+		if key == "provider" && !isKnownProvider(value) {
+			fmt.Printf("Error: proveedor no válido: %s (usa %s)\n", value, strings.Join(api.Providers(), ", "))
+			return
+		}
+
 		err := config.Set(key, value)
 		if err != nil {
 			fmt.Printf("Error: %v\n", err)
@@ -60,7 +81,10 @@ var configGetCmd = &cobra.Command{
 	Long: `Obtener un valor de configuración específico.
 
 Claves disponibles:
-  apikey, lang, default-city, color, default-limit
+  apikey, lang, default-city, color, default-limit, provider, overpass-url,
+  country, search-lang, hours-lookups, hours-concurrency, api-base-url,
+  retries, retry-max-wait, notify-command, notify-fifo, notify-webhook,
+  notify-dbus
 
 Ejemplo:
   pingbar config get lang`,
@@ -100,13 +124,13 @@ var configListCmd = &cobra.Command{
 		fmt.Println("Configuración actual:")
 		fmt.Println()
 
-		keys := []string{"apikey", "lang", "default-city", "color", "default-limit"}
+		keys := []string{"apikey", "lang", "default-city", "color", "default-limit", "provider", "overpass-url", "country", "search-lang", "hours-lookups", "hours-concurrency", "api-base-url", "retries", "retry-max-wait", "notify-command", "notify-fifo", "notify-webhook", "notify-dbus"}
 		for _, key := range keys {
 			value := configMap[key]
 			if value == "" {
 				value = "(no configurado)"
 			}
-			fmt.Printf("  %-14s = %s\n", key, value)
+			fmt.Printf("  %-17s = %s\n", key, value)
 		}
 
 		fmt.Println()
@@ -131,3 +155,13 @@ func maskAPIKey(key string) string {
 	return key[:4] + "..." + key[len(key)-4:]
 }
 
+
+// isKnownProvider comprueba si hay un proveedor registrado con ese nombre
+func isKnownProvider(name string) bool {
+	for _, p := range api.Providers() {
+		if p == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"errors"
+
+	"github.com/686f6c61/pingbar/internal/api"
+)
+
+// Códigos de salida. Los tres primeros indican el estado del primer
+// resultado, como ping con un host que responde o no:
+//
+//	pingbar bar madrid && echo "vamos"
+//
+// Los códigos a partir de 3 son errores.
+const (
+	exitOpen       = 0   // El primer resultado está abierto
+	exitClosed     = 1   // El primer resultado está cerrado
+	exitUnknown    = 2   // No se conoce el horario del primer resultado
+	exitError      = 3   // Error de uso o de configuración
+	exitNoAPIKey   = 4   // No hay API Key configurada
+	exitInvalidKey = 5   // API Key inválida o expirada
+	exitQuota      = 6   // Límite de la API alcanzado
+	exitNetwork    = 7   // Error de red, plazo agotado o servidor caído
+	exitNotFound   = 8   // La búsqueda no devolvió resultados (no es un error: ver statusCode)
+	exitParse      = 9   // La API devolvió una respuesta no válida
+	exitCancelled  = 130 // Búsqueda cancelada con Ctrl-C (128 + SIGINT)
+)
+
+// exitCode devuelve el código de salida de un error
+func exitCode(err error) int {
+	switch {
+	case errors.Is(err, api.ErrNoAPIKey):
+		return exitNoAPIKey
+	case errors.Is(err, api.ErrInvalidKey):
+		return exitInvalidKey
+	case errors.Is(err, api.ErrQuota):
+		return exitQuota
+	case errors.Is(err, api.ErrNetwork):
+		return exitNetwork
+	case errors.Is(err, api.ErrParse):
+		return exitParse
+	case errors.Is(err, api.ErrCancelled):
+		return exitCancelled
+	default:
+		return exitError
+	}
+}
+
+// statusCode devuelve el código de salida según el estado del primer resultado
+func statusCode(results []api.BusinessInfo) int {
+	if len(results) == 0 {
+		return exitNotFound
+	}
+	switch top := results[0]; {
+	case top.IsUnknown:
+		return exitUnknown
+	case top.IsOpen:
+		return exitOpen
+	default:
+		return exitClosed
+	}
+}
@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/686f6c61/pingbar/internal/api"
+	"github.com/686f6c61/pingbar/internal/notify"
+	"github.com/spf13/cobra"
+)
+
+var (
+	notifyInterval time.Duration
+	notifyOnce     bool
+)
+
+// notifyCmd avisa cuando un negocio abre
+var notifyCmd = &cobra.Command{
+	Use:   "notify <negocio> <ciudad>",
+	Short: "Avisar cuando un negocio abra",
+	Long: `Vigila el negocio como pingbar watch y, cada vez que pasa de cerrado a
+abierto, envía un aviso por los notificadores configurados:
+
+  notify-command - Ejecuta un comando (recibe PINGBAR_NAME, PINGBAR_ADDRESS
+                   y PINGBAR_HOURS en el entorno)
+  notify-fifo    - Escribe el aviso en JSON en un FIFO o un archivo
+  notify-webhook - Envía el aviso en JSON con un POST a una URL
+  notify-dbus    - Notificación de escritorio por D-Bus (on/off/auto)
+
+Sigue vigilando hasta Ctrl-C, o hasta el primer aviso con --once.
+
+Ejemplos:
+  pingbar config set notify-webhook https://example.com/hooks/pingbar
+  pingbar notify "bar pepe" madrid --interval 1m
+  pingbar notify "farmacia" madrid --once`,
+	Args: cobra.RangeArgs(1, 2),
+	Run: func(cmd *cobra.Command, args []string) {
+		business, city := searchArgs(args)
+		runNotify(business, city)
+	},
+}
+
+func init() {
+	notifyCmd.Flags().DurationVar(&notifyInterval, "interval", 5*time.Minute, "Tiempo entre comprobaciones (mínimo 1s)")
+	notifyCmd.Flags().BoolVar(&notifyOnce, "once", false, "Terminar después del primer aviso")
+}
+
+// runNotify vigila el primer resultado de la búsqueda y avisa cuando abre
+func runNotify(business, city string) {
+	checkInterval(notifyInterval)
+	s := newSearcher()
+
+	notifiers, err := notify.FromConfig(notify.Config{
+		Command: s.cfg.NotifyCommand,
+		FIFO:    s.cfg.NotifyFIFO,
+		Webhook: s.cfg.NotifyWebhook,
+		DBus:    s.cfg.NotifyDBus,
+
+		JSONLang: jsonLang(),
+	})
+	if err != nil {
+		if !quietFlag {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		}
+		os.Exit(exitError)
+	}
+
+	// Ctrl-C termina la vigilancia
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	info := watchTarget(ctx, s, business, city)
+
+	open := poll(ctx, s, info, notifyInterval, func(forecast api.Forecast, opened bool) bool {
+		if !opened {
+			return true
+		}
+		if err := notify.Send(ctx, notifiers, openEvent(s.lang, info, forecast)); err != nil && !quietFlag {
+			fmt.Fprintf(os.Stderr, "Error al enviar el aviso: %v\n", err)
+		}
+		return !notifyOnce
+	})
+
+	if open {
+		os.Exit(exitOpen)
+	}
+	os.Exit(exitClosed)
+}
@@ -3,6 +3,8 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/686f6c61/pingbar/internal/config"
 	"github.com/686f6c61/pingbar/internal/output"
@@ -17,6 +19,20 @@ var (
 	langFlag   string
 	noColor    bool
 	limitFlag  int
+	noCache    bool
+	refreshCache bool
+	timeFlag   string
+	countryFlag string
+	hoursForFlag int
+	timeoutFlag time.Duration
+	verboseFlag bool
+	quietFlag  bool
+	atFlag     string
+	tzFlag     string
+	jsonLangFlag string
+	formatFlag string
+	templateFlag string
+	templateFileFlag string
 
 	// Versión
 	Version = "0.0.1"
@@ -35,7 +51,9 @@ devuelve si el establecimiento está abierto o cerrado, junto con su horario.
 Ejemplos:
   pingbar "el corte ingles" madrid
   pingbar "farmacia" madrid
-  pingbar "mercadona" barcelona`,
+  pingbar "mercadona" barcelona
+  pingbar -q "bar pepe" madrid && echo "abierto"
+  pingbar "farmacia" madrid --at "sábado por la tarde"`,
 	Args: cobra.MinimumNArgs(0),
 	Run: func(cmd *cobra.Command, args []string) {
 		// Si no hay argumentos, mostrar ayuda o mensaje de bienvenida
@@ -50,39 +68,58 @@ Ejemplos:
 			return
 		}
 
-		// Necesitamos al menos negocio y ciudad
-		if len(args) < 2 {
-			// Verificar si hay ciudad por defecto
-			cfg, _ := config.Load()
-			if cfg.DefaultCity == "" {
-				fmt.Println("Uso: pingbar <negocio> <ciudad>")
-				fmt.Println("O configura una ciudad por defecto: pingbar config set default-city <ciudad>")
-				os.Exit(1)
-			}
-			// Usar ciudad por defecto
-			runSearch(args[0], cfg.DefaultCity)
-			return
-		}
-
-		runSearch(args[0], args[1])
+		runSearch(searchArgs(args))
 	},
 }
 
+// searchArgs devuelve el negocio y la ciudad de los argumentos. Sin ciudad
+// se usa la ciudad por defecto; si tampoco hay, muestra el uso y termina.
+func searchArgs(args []string) (string, string) {
+	if len(args) >= 2 {
+		return args[0], args[1]
+	}
+
+	// Verificar si hay ciudad por defecto
+	cfg, _ := config.Load()
+	if cfg.DefaultCity == "" {
+		if !quietFlag {
+			fmt.Println("Uso: pingbar <negocio> <ciudad>")
+			fmt.Println("O configura una ciudad por defecto: pingbar config set default-city <ciudad>")
+		}
+		os.Exit(exitError)
+	}
+	return args[0], cfg.DefaultCity
+}
+
 // Execute ejecuta el comando raíz
 func Execute() {
 	if err := rootCmd.Execute(); err != nil {
-		os.Exit(1)
+		os.Exit(exitError)
 	}
 }
 
 func init() {
 	// Flags globales
-	rootCmd.PersistentFlags().BoolVar(&jsonOutput, "json", false, "Salida en formato JSON")
+	rootCmd.PersistentFlags().BoolVar(&jsonOutput, "json", false, "Salida en formato JSON (igual que --format json)")
+	rootCmd.PersistentFlags().StringVarP(&formatFlag, "format", "o", "", "Formato de salida: "+strings.Join(output.Formats(), ", ")+" (por defecto text)")
+	rootCmd.PersistentFlags().StringVar(&templateFlag, "template", "", "Imprimir cada resultado con una plantilla de text/template ('{{.Name}}: {{.StatusText}}')")
+	rootCmd.PersistentFlags().StringVar(&templateFileFlag, "template-file", "", "Archivo con la plantilla de cada resultado")
+	rootCmd.PersistentFlags().StringVar(&jsonLangFlag, "json-lang", "en", "Idioma de las claves de la salida JSON (en|es)")
 	rootCmd.PersistentFlags().BoolVar(&showWeek, "week", false, "Mostrar horario completo de la semana")
 	rootCmd.PersistentFlags().BoolVar(&showTomorrow, "tomorrow", false, "Mostrar horario de mañana")
+	rootCmd.PersistentFlags().StringVar(&timeFlag, "time", "", "Hora a comprobar con --tomorrow (HH:MM, por defecto la hora actual)")
+	rootCmd.PersistentFlags().StringVar(&atFlag, "at", "", "Comprobar el estado en otro momento (\"2026-10-18 21:30\", \"sábado 14:00\", \"saturday 2pm\")")
+	rootCmd.PersistentFlags().StringVar(&tzFlag, "tz", "", "Zona horaria IANA del negocio (por ejemplo Atlantic/Canary; por defecto, la de su ubicación)")
 	rootCmd.PersistentFlags().StringVar(&langFlag, "lang", "", "Idioma de salida (es|en)")
+	rootCmd.PersistentFlags().StringVar(&countryFlag, "country", "", "País de búsqueda (código de dos letras, por defecto el configurado)")
 	rootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "Desactivar colores en la salida")
 	rootCmd.PersistentFlags().IntVar(&limitFlag, "limit", 0, "Limitar número de resultados (máximo 50)")
+	rootCmd.PersistentFlags().IntVar(&hoursForFlag, "hours-for", 0, "Número de horarios a consultar a la API (por defecto, hours-lookups)")
+	rootCmd.PersistentFlags().BoolVar(&noCache, "no-cache", false, "No leer ni escribir la caché local")
+	rootCmd.PersistentFlags().BoolVar(&refreshCache, "refresh", false, "Ignorar la caché y volver a consultar la API")
+	rootCmd.PersistentFlags().DurationVar(&timeoutFlag, "timeout", 0, "Tiempo máximo de la búsqueda completa (por ejemplo 10s; 0 = sin límite)")
+	rootCmd.PersistentFlags().BoolVarP(&verboseFlag, "verbose", "v", false, "Explicar por qué falta un horario y mostrar el detalle de los errores")
+	rootCmd.PersistentFlags().BoolVarP(&quietFlag, "quiet", "q", false, "No mostrar nada; el código de salida indica si el primer resultado está abierto (0), cerrado (1) o sin horario (2)")
 
 	// Añadir subcomandos
 	rootCmd.AddCommand(configCmd)
@@ -90,6 +127,10 @@ func init() {
 	rootCmd.AddCommand(aboutCmd)
 	rootCmd.AddCommand(uninstallCmd)
 	rootCmd.AddCommand(versionCmd)
+	rootCmd.AddCommand(watchCmd)
+	rootCmd.AddCommand(notifyCmd)
+	rootCmd.AddCommand(batchCmd)
+	rootCmd.AddCommand(schemaCmd)
 }
 
 // versionCmd muestra la versión
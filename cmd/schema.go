@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/686f6c61/pingbar/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// schemaCmd imprime el JSON Schema de la salida JSON
+var schemaCmd = &cobra.Command{
+	Use:   "schema [search|ndjson|batch|watch|notify]",
+	Short: "Mostrar el JSON Schema de la salida JSON",
+	Long: `Imprime el JSON Schema (draft 2020-12) de la salida de --json:
+
+  search - pingbar <negocio> <ciudad> --json (por defecto)
+  ndjson - cada línea de pingbar <negocio> <ciudad> --format ndjson
+  batch  - cada línea de pingbar batch --json
+  watch  - cada línea de pingbar watch --json y pingbar notify --json
+  notify - el aviso que reciben notify-webhook y notify-fifo
+
+Las claves están en inglés; con --json-lang es, en español. Todos los
+documentos llevan schema_version, que solo cambia si se quita o cambia de
+significado algún campo.
+
+Ejemplos:
+  pingbar schema > pingbar.schema.json
+  pingbar schema batch --json-lang es`,
+	Args:      cobra.MaximumNArgs(1),
+	ValidArgs: output.SchemaNames,
+	Run: func(cmd *cobra.Command, args []string) {
+		name := "search"
+		if len(args) > 0 {
+			name = strings.ToLower(args[0])
+		}
+
+		schema, err := output.Schema(name, jsonLang())
+		if err != nil {
+			usageError(err)
+		}
+		fmt.Println(string(schema))
+	},
+}
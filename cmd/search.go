@@ -1,26 +1,40 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
+	"os/signal"
+	"strings"
+	"time"
 
 	"github.com/686f6c61/pingbar/internal/api"
 	"github.com/686f6c61/pingbar/internal/config"
 	"github.com/686f6c61/pingbar/internal/output"
+	"github.com/686f6c61/pingbar/internal/schedule"
 )
 
-// runSearch ejecuta la búsqueda principal
-func runSearch(business, city string) {
+// searcher reúne la configuración, el proveedor y las opciones de búsqueda
+// que resultan de la configuración y los flags globales
+type searcher struct {
+	cfg       *config.Config
+	lang      string
+	formatter *output.Formatter
+	provider  api.Provider
+	opts      api.SearchOptions
+	limit     int
+}
+
+// newSearcher carga la configuración y crea el proveedor de búsqueda.
+// Si algo falla, muestra el error y termina con su código de salida.
+func newSearcher() *searcher {
 	cfg, err := config.Load()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error al cargar configuración: %v\n", err)
-		os.Exit(1)
-	}
-
-	// Verificar API key
-	if cfg.APIKey == "" {
-		output.PrintWelcome(cfg.Lang)
-		os.Exit(1)
+		if !quietFlag {
+			fmt.Fprintf(os.Stderr, "Error al cargar configuración: %v\n", err)
+		}
+		os.Exit(exitError)
 	}
 
 	// Determinar idioma
@@ -44,21 +58,239 @@ func runSearch(business, city string) {
 			limit = limitFlag
 		}
 	}
+	// En modo silencioso solo cuenta el primer resultado
+	if quietFlag {
+		limit = 1
+	}
 
 	// Crear formateador de salida
-	formatter := output.NewFormatter(lang, colorMode, jsonOutput)
+	formatter, err := output.NewFormatter(lang, colorMode, outputFormat())
+	if err != nil {
+		usageError(err)
+	}
+	if formatter.Format == "template" {
+		if err := formatter.SetTemplate(templateText()); err != nil {
+			usageError(err)
+		}
+	}
+	formatter.Verbose = verboseFlag
+	formatter.JSONLang = jsonLang()
+
+	// Crear proveedor de búsqueda
+	provider, err := api.NewProvider(cfg.Provider, api.ProviderConfig{
+		APIKey:      cfg.APIKey,
+		BaseURL:     cfg.APIBaseURL,
+		OverpassURL: cfg.OverpassURL,
+		Retry: api.RetryPolicy{
+			Retries: cfg.Retries,
+			MaxWait: cfg.RetryMaxWait,
+		},
+	})
+	if err != nil {
+		if !quietFlag && errors.Is(err, api.ErrNoAPIKey) {
+			output.PrintWelcome(lang)
+			os.Exit(exitCode(err))
+		}
+		fail(err, lang)
+	}
+
+	opts := api.SearchOptions{
+		NoCache:          noCache,
+		Refresh:          refreshCache,
+		HoursLookups:     cfg.HoursLookups,
+		HoursConcurrency: cfg.HoursWorkers,
+	}
+	if hoursForFlag > 0 {
+		opts.HoursLookups = hoursForFlag
+	}
+	if tzFlag != "" {
+		loc, err := time.LoadLocation(tzFlag)
+		if err != nil {
+			usageError(fmt.Errorf("zona horaria no válida: %s (usa un nombre IANA, por ejemplo Europe/Madrid)", tzFlag))
+		}
+		opts.Location = loc
+	}
+
+	return &searcher{
+		cfg:       cfg,
+		lang:      lang,
+		formatter: formatter,
+		provider:  provider,
+		opts:      opts,
+		limit:     limit,
+	}
+}
+
+// outputFormat devuelve el formato de salida de --format; --json equivale a
+// --format json y --template o --template-file, a --format template
+func outputFormat() string {
+	format, source := formatFlag, "--format "+formatFlag
+	implied := func(flag, name string) {
+		if format != "" && format != name {
+			usageError(fmt.Errorf("%s y %s no se pueden usar a la vez", source, flag))
+		}
+		format, source = name, flag
+	}
+
+	if jsonOutput {
+		implied("--json", "json")
+	}
+	if templateFlag != "" {
+		implied("--template", "template")
+	}
+	if templateFileFlag != "" {
+		implied("--template-file", "template")
+	}
+	return format
+}
+
+// templateText devuelve la plantilla de --template o de --template-file
+func templateText() string {
+	switch {
+	case templateFlag != "" && templateFileFlag != "":
+		usageError(fmt.Errorf("--template y --template-file no se pueden usar a la vez"))
+	case templateFileFlag != "":
+		data, err := os.ReadFile(templateFileFlag)
+		if err != nil {
+			usageError(err)
+		}
+		return string(data)
+	case templateFlag == "":
+		usageError(fmt.Errorf("el formato template necesita --template o --template-file"))
+	}
+	return templateFlag
+}
+
+// jsonLang valida --json-lang y devuelve el idioma de las claves JSON
+func jsonLang() string {
+	for _, lang := range output.JSONLanguages {
+		if jsonLangFlag == lang {
+			return lang
+		}
+	}
+	usageError(fmt.Errorf("idioma JSON no válido: %s (usa %s)", jsonLangFlag, strings.Join(output.JSONLanguages, " o ")))
+	return ""
+}
+
+// query construye la consulta para un negocio y una ciudad
+func (s *searcher) query(business, city string) api.Query {
+	query := api.Query{
+		Business: business,
+		City:     city,
+		Country:  s.cfg.Country,
+		Lang:     s.cfg.SearchLang,
+	}
+	if countryFlag != "" {
+		// Otro país implica su idioma salvo que se haya fijado search-lang
+		query.Country = countryFlag
+	}
+	return query
+}
+
+// search busca un negocio en una ciudad. --timeout limita cada búsqueda.
+func (s *searcher) search(ctx context.Context, business, city string) ([]api.BusinessInfo, error) {
+	return s.searchWith(ctx, business, city, s.limit, s.opts)
+}
+
+// searchWith busca como search pero con otro límite y otras opciones, para
+// búsquedas simultáneas que no deben compartir el estado del searcher
+func (s *searcher) searchWith(ctx context.Context, business, city string, limit int, opts api.SearchOptions) ([]api.BusinessInfo, error) {
+	if timeoutFlag > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeoutFlag)
+		defer cancel()
+	}
+	return api.Search(ctx, s.provider, s.query(business, city), limit, opts)
+}
+
+// fail muestra un error (salvo con --quiet) y termina con su código de salida
+func fail(err error, lang string) {
+	if !quietFlag {
+		output.PrintError(err, lang)
+		if verboseFlag {
+			fmt.Fprintln(os.Stderr, err)
+		}
+	}
+	os.Exit(exitCode(err))
+}
+
+// usageError muestra un error en los argumentos (salvo con --quiet) y termina
+func usageError(err error) {
+	if !quietFlag {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+	}
+	os.Exit(exitError)
+}
+
+// location devuelve la zona horaria de la ciudad buscada, en la que se
+// interpretan --at y --time
+func (s *searcher) location(business, city string) *time.Location {
+	return api.Location(s.query(business, city), "", s.opts.Location)
+}
+
+// runSearch ejecuta la búsqueda principal
+func runSearch(business, city string) {
+	s := newSearcher()
+
+	// Validar las fechas antes de gastar créditos en la búsqueda
+	at := time.Now().In(s.location(business, city))
+	if atFlag != "" {
+		var err error
+		if at, err = schedule.ParseAt(atFlag, at); err != nil {
+			usageError(err)
+		}
+	}
+	var tomorrowAt time.Time
+	if showTomorrow {
+		var err error
+		if tomorrowAt, err = tomorrowTime(timeFlag, at); err != nil {
+			usageError(err)
+		}
+	}
+
+	if atFlag != "" {
+		s.opts.At = at
+		s.formatter.At = at
+	}
+
+	// Ctrl-C cancela la búsqueda en curso
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
 
 	// Buscar (incluye extracción de horarios de snippets)
-	results, err := api.Search(cfg.APIKey, business, city, limit)
+	results, err := s.search(ctx, business, city)
 	if err != nil {
-		if apiErr, ok := err.(*api.APIError); ok {
-			output.PrintError(apiErr.Type, lang)
-		} else {
-			output.PrintError(err.Error(), lang)
+		fail(err, s.lang)
+	}
+
+	if showTomorrow {
+		for i := range results {
+			forecast := api.ForecastAt(results[i], tomorrowAt)
+			results[i].Tomorrow = &forecast
 		}
-		os.Exit(1)
 	}
 
 	// Mostrar resultados
-	formatter.PrintResults(results, business, city, showWeek)
+	if !quietFlag {
+		s.formatter.PrintResults(results, business, city, showWeek)
+	}
+
+	os.Exit(statusCode(results))
+}
+
+// tomorrowTime devuelve el instante del día siguiente a now a la hora
+// indicada (HH:MM). Sin hora, usa la misma hora del día que now.
+func tomorrowTime(clock string, now time.Time) (time.Time, error) {
+	tomorrow := now.AddDate(0, 0, 1)
+	if clock == "" {
+		return tomorrow, nil
+	}
+
+	t, err := time.Parse("15:04", clock)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("hora no válida: %s (usa HH:MM)", clock)
+	}
+
+	return time.Date(tomorrow.Year(), tomorrow.Month(), tomorrow.Day(),
+		t.Hour(), t.Minute(), 0, 0, tomorrow.Location()), nil
 }
@@ -0,0 +1,159 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/686f6c61/pingbar/internal/api"
+	"github.com/686f6c61/pingbar/internal/i18n"
+	"github.com/686f6c61/pingbar/internal/notify"
+	"github.com/686f6c61/pingbar/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var (
+	watchInterval time.Duration
+	watchExec     string
+)
+
+// watchCmd vigila un negocio hasta que abre
+var watchCmd = &cobra.Command{
+	Use:   "watch <negocio> <ciudad>",
+	Short: "Vigilar un negocio hasta que abra",
+	Long: `Comprueba el estado del negocio cada cierto tiempo, como ping, e imprime
+una línea por comprobación.
+
+Solo la primera comprobación consulta la API (o la caché); las siguientes
+recalculan el estado con el horario ya obtenido, sin gastar créditos.
+
+Sin --exec, termina con código 0 en cuanto el negocio está abierto. Con
+--exec, ejecuta el comando cada vez que pasa de cerrado a abierto y sigue
+vigilando hasta Ctrl-C. El comando recibe PINGBAR_NAME, PINGBAR_ADDRESS y
+PINGBAR_HOURS en el entorno.
+
+Ejemplos:
+  pingbar watch "bar pepe" madrid && echo "Ya ha abierto"
+  pingbar watch "farmacia" madrid --interval 1m
+  pingbar watch "mercadona" barcelona --exec 'notify-send "$PINGBAR_NAME ha abierto"'`,
+	Args: cobra.RangeArgs(1, 2),
+	Run: func(cmd *cobra.Command, args []string) {
+		business, city := searchArgs(args)
+		runWatch(business, city)
+	},
+}
+
+func init() {
+	watchCmd.Flags().DurationVar(&watchInterval, "interval", 5*time.Minute, "Tiempo entre comprobaciones (mínimo 1s)")
+	watchCmd.Flags().StringVar(&watchExec, "exec", "", "Comando a ejecutar cada vez que el negocio abre")
+}
+
+// runWatch vigila el primer resultado de la búsqueda
+func runWatch(business, city string) {
+	checkInterval(watchInterval)
+	s := newSearcher()
+
+	// Ctrl-C termina la vigilancia
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	info := watchTarget(ctx, s, business, city)
+
+	var hook notify.Notifier
+	if watchExec != "" {
+		hook = notify.NewCommand(watchExec)
+	}
+
+	open := poll(ctx, s, info, watchInterval, func(forecast api.Forecast, opened bool) bool {
+		if hook == nil {
+			return !forecast.IsOpen
+		}
+		if opened {
+			if err := hook.Notify(ctx, openEvent(s.lang, info, forecast)); err != nil && !quietFlag {
+				fmt.Fprintf(os.Stderr, "Error al ejecutar --exec: %v\n", err)
+			}
+		}
+		return true
+	})
+
+	if open {
+		os.Exit(exitOpen)
+	}
+	os.Exit(exitClosed)
+}
+
+// checkInterval termina si el intervalo entre comprobaciones no es válido
+func checkInterval(interval time.Duration) {
+	if interval < time.Second {
+		if !quietFlag {
+			fmt.Fprintf(os.Stderr, "Error: intervalo no válido: %s (mínimo 1s)\n", interval)
+		}
+		os.Exit(exitError)
+	}
+}
+
+// watchTarget busca el negocio a vigilar, el primer resultado. Si no hay
+// resultados o no se conoce su horario no hay nada que vigilar: lo muestra y
+// termina con el código de salida correspondiente.
+func watchTarget(ctx context.Context, s *searcher, business, city string) api.BusinessInfo {
+	s.limit = 1
+	results, err := s.search(ctx, business, city)
+	if err != nil {
+		fail(err, s.lang)
+	}
+	if len(results) == 0 || results[0].IsUnknown {
+		if !quietFlag {
+			s.formatter.PrintResults(results, business, city, false)
+		}
+		os.Exit(statusCode(results))
+	}
+	return results[0]
+}
+
+// poll comprueba el estado de info cada intervalo con el horario ya obtenido,
+// sin consultar la API, e imprime una línea por comprobación. check recibe
+// cada comprobación, con opened a true si el negocio acaba de pasar de
+// cerrado a abierto, y devuelve si hay que seguir. poll termina también con
+// Ctrl-C y devuelve si el negocio estaba abierto en la última comprobación.
+func poll(ctx context.Context, s *searcher, info api.BusinessInfo, interval time.Duration, check func(forecast api.Forecast, opened bool) bool) bool {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	first := true
+	wasOpen := false
+	for {
+		forecast := api.ForecastAt(info, time.Now())
+		if !quietFlag {
+			s.formatter.PrintTick(info, forecast)
+		}
+
+		opened := !first && !wasOpen && forecast.IsOpen
+		first = false
+		wasOpen = forecast.IsOpen
+		if !check(forecast, opened) {
+			return wasOpen
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return wasOpen
+		}
+	}
+}
+
+// openEvent describe la apertura de un negocio para los notificadores
+func openEvent(lang string, info api.BusinessInfo, forecast api.Forecast) notify.Event {
+	msgs := i18n.Get(i18n.Lang(lang))
+	hours := output.DayHours(msgs, forecast.Day, forecast.Hours)
+	return notify.Event{
+		Title:   fmt.Sprintf(msgs.NotifyTitle, info.Name),
+		Message: fmt.Sprintf(msgs.NotifyMessage, hours),
+		Name:    info.Name,
+		Address: info.Address,
+		Hours:   hours,
+		At:      forecast.At,
+	}
+}
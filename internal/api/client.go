@@ -0,0 +1,170 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Client envía las peticiones HTTP de los proveedores. Todas las peticiones
+// reciben un context.Context, de modo que una búsqueda se puede cancelar
+// (Ctrl-C) o limitar con un plazo global.
+type Client struct {
+	HTTP  *http.Client
+	Retry RetryPolicy
+
+	// StatusError traduce los códigos de error propios de una API y decide
+	// con ellos qué respuestas se reintentan; nil para statusError
+	StatusError func(resp *Response) error
+}
+
+// NewClient crea un cliente que envía las peticiones con transport.
+// Si transport es nil se usa http.DefaultTransport.
+func NewClient(transport http.RoundTripper) *Client {
+	return &Client{HTTP: &http.Client{Transport: transport}}
+}
+
+// Response es la respuesta de una petición ya leída por completo
+type Response struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
+
+// PostJSON envía body codificado en JSON. timeout limita esta petición
+// además del plazo que ya tenga ctx.
+func (c *Client) PostJSON(ctx context.Context, endpoint string, headers map[string]string, body interface{}, timeout time.Duration) (*Response, error) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, requestBuildError(err)
+	}
+	if headers == nil {
+		headers = map[string]string{}
+	}
+	headers["Content-Type"] = "application/json"
+	return c.post(ctx, endpoint, headers, data, timeout)
+}
+
+// PostForm envía un formulario application/x-www-form-urlencoded
+func (c *Client) PostForm(ctx context.Context, endpoint string, headers map[string]string, form url.Values, timeout time.Duration) (*Response, error) {
+	if headers == nil {
+		headers = map[string]string{}
+	}
+	headers["Content-Type"] = "application/x-www-form-urlencoded"
+	return c.post(ctx, endpoint, headers, []byte(form.Encode()), timeout)
+}
+
+// post envía la petición y la repite según la política de reintentos
+// mientras la respuesta sea un fallo transitorio (429, 5xx o error de red)
+func (c *Client) post(ctx context.Context, endpoint string, headers map[string]string, body []byte, timeout time.Duration) (*Response, error) {
+	for attempt := 0; ; attempt++ {
+		resp, err := c.send(ctx, endpoint, headers, body, timeout)
+
+		// La búsqueda se ha cancelado o ha vencido su plazo global
+		if ctxErr := contextError(ctx); ctxErr != nil {
+			return nil, ctxErr
+		}
+		if attempt >= c.Retry.Retries || !transient(resp, err, c.statusError) {
+			return resp, err
+		}
+
+		wait, ok := c.Retry.delay(attempt, resp)
+		if !ok {
+			return resp, err
+		}
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return nil, contextError(ctx)
+		}
+	}
+}
+
+// send envía una única petición; timeout la limita además del plazo de ctx
+func (c *Client) send(ctx context.Context, endpoint string, headers map[string]string, body []byte, timeout time.Duration) (*Response, error) {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, requestBuildError(err)
+	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := c.HTTP.Do(req)
+	if err != nil {
+		return nil, requestError(ctx, err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, requestError(ctx, err)
+	}
+
+	return &Response{StatusCode: resp.StatusCode, Header: resp.Header, Body: data}, nil
+}
+
+// statusError traduce un código de estado de error con la función de la API
+// del cliente, o con la común si no tiene
+func (c *Client) statusError(resp *Response) error {
+	if c.StatusError != nil {
+		return c.StatusError(resp)
+	}
+	return statusError(resp)
+}
+
+// statusError traduce un código de estado de error común a todas las APIs.
+// Un 429 es un límite de frecuencia, que se reintenta.
+func statusError(resp *Response) error {
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		return &APIError{Type: ErrorRateLimited, Message: "Demasiadas peticiones seguidas"}
+	case resp.StatusCode >= 500:
+		return &APIError{Type: ErrorServer, Message: fmt.Sprintf("Error del servidor: %d", resp.StatusCode)}
+	default:
+		return &APIError{Type: ErrorUnknown, Message: fmt.Sprintf("Error de API: %d", resp.StatusCode)}
+	}
+}
+
+// requestError traduce el fallo de una petición a un APIError
+func requestError(ctx context.Context, err error) error {
+	if ctxErr := contextError(ctx); ctxErr != nil {
+		return ctxErr
+	}
+	return &APIError{Type: ErrorConnection, Message: fmt.Sprintf("Error de conexión: %v", err)}
+}
+
+// requestBuildError indica que no se pudo construir la petición (URL base
+// mal escrita, cuerpo que no se puede codificar...). No se reintenta.
+func requestBuildError(err error) error {
+	return &APIError{Type: ErrorInvalidRequest, Message: fmt.Sprintf("Petición no válida: %v", err)}
+}
+
+// responseError indica que la respuesta de la API no tiene el formato esperado
+func responseError(err error) error {
+	return &APIError{Type: ErrorInvalidResponse, Message: fmt.Sprintf("Respuesta de la API no válida: %v", err)}
+}
+
+// contextError devuelve un APIError si el contexto se ha cancelado o ha
+// vencido su plazo, o nil si sigue activo
+func contextError(ctx context.Context) error {
+	switch ctx.Err() {
+	case nil:
+		return nil
+	case context.DeadlineExceeded:
+		return &APIError{Type: ErrorTimeout, Message: "Tiempo de espera agotado"}
+	default:
+		return &APIError{Type: ErrorCancelled, Message: "Búsqueda cancelada"}
+	}
+}
@@ -0,0 +1,76 @@
+package api
+
+import "errors"
+
+// Errores de la API agrupados por su causa. Se comparan con errors.Is:
+//
+//	if errors.Is(err, api.ErrInvalidKey) { ... }
+//
+// APIError.Type conserva el detalle (por ejemplo, un límite de frecuencia
+// y los créditos agotados son ambos ErrQuota).
+var (
+	ErrNoAPIKey   = errors.New("API Key no configurada")
+	ErrInvalidKey = errors.New("API Key inválida")
+	ErrQuota      = errors.New("límite de la API alcanzado")
+	ErrNetwork    = errors.New("error de red")
+	ErrParse      = errors.New("respuesta de la API no válida")
+	ErrCancelled  = errors.New("búsqueda cancelada")
+)
+
+// ErrorType identifica el tipo concreto de un APIError
+type ErrorType string
+
+const (
+	ErrorNoAPIKey        ErrorType = "no_api_key"
+	ErrorInvalidKey      ErrorType = "invalid_key"
+	ErrorLimitReached    ErrorType = "limit_reached" // Créditos agotados
+	ErrorRateLimited     ErrorType = "rate_limited"  // Demasiadas peticiones seguidas
+	ErrorConnection      ErrorType = "connection"
+	ErrorTimeout         ErrorType = "timeout"
+	ErrorServer          ErrorType = "server_error"
+	ErrorCancelled       ErrorType = "cancelled"
+	ErrorInvalidResponse ErrorType = "invalid_response"
+	ErrorInvalidRequest  ErrorType = "invalid_request"
+	ErrorUnknown         ErrorType = "unknown"
+)
+
+// APIError representa un error de la API
+type APIError struct {
+	Type    ErrorType
+	Message string
+}
+
+func (e *APIError) Error() string {
+	return e.Message
+}
+
+// Is hace que errors.Is reconozca el error como uno de los errores
+// centinela según su tipo
+func (e *APIError) Is(target error) bool {
+	switch e.Type {
+	case ErrorNoAPIKey:
+		return target == ErrNoAPIKey
+	case ErrorInvalidKey:
+		return target == ErrInvalidKey
+	case ErrorLimitReached, ErrorRateLimited:
+		return target == ErrQuota
+	case ErrorConnection, ErrorTimeout, ErrorServer:
+		return target == ErrNetwork
+	case ErrorInvalidResponse:
+		return target == ErrParse
+	case ErrorCancelled:
+		return target == ErrCancelled
+	}
+	return false
+}
+
+// Temporary indica si el error es transitorio y la petición puede repetirse
+// más tarde: red, plazos, límites de frecuencia y errores del servidor.
+// "limit_reached" (créditos agotados) o "invalid_key" son definitivos.
+func (e *APIError) Temporary() bool {
+	switch e.Type {
+	case ErrorConnection, ErrorTimeout, ErrorRateLimited, ErrorServer:
+		return true
+	}
+	return false
+}
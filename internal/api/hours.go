@@ -0,0 +1,285 @@
+package api
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/686f6c61/pingbar/internal/holidays"
+	"github.com/686f6c61/pingbar/internal/locale"
+	"github.com/686f6c61/pingbar/internal/schedule"
+)
+
+// extractHours obtiene el horario en texto libre y el horario semanal más
+// completo de los datos de un proveedor. Las excepciones de opening_hours
+// (festivos, fechas concretas) se resuelven para la semana de at.
+func extractHours(data HoursData, q Query, at time.Time) (string, *schedule.Week) {
+	if data.OpeningHours != "" {
+		if oh, err := schedule.ParseOpeningHours(data.OpeningHours); err == nil {
+			isHoliday := func(date time.Time) bool {
+				_, ok := holidays.Lookup(q.Country, q.City, date)
+				return ok
+			}
+			return "", oh.Week(at, isHoliday)
+		}
+	}
+
+	hoursInfo := ""
+	best := data.Schedule
+	for _, snippet := range data.Snippets {
+		if hoursInfo == "" {
+			hoursInfo = extractHoursFromText(snippet, q.Lang)
+		}
+		if week := schedule.ParseLang(snippet, q.Lang); betterSchedule(week, best) {
+			best = week
+		}
+	}
+
+	return hoursInfo, best
+}
+
+// betterSchedule indica si a tiene más días con horario explícito que b
+func betterSchedule(a, b *schedule.Week) bool {
+	if a == nil {
+		return false
+	}
+	if b == nil {
+		return true
+	}
+	return explicitDays(a) > explicitDays(b)
+}
+
+func explicitDays(w *schedule.Week) int {
+	count := 0
+	for _, d := range w.Days {
+		if d.Known && !d.Assumed {
+			count++
+		}
+	}
+	return count
+}
+
+// extractHoursFromText extrae información de horario de un texto usando las
+// palabras clave del idioma de búsqueda
+func extractHoursFromText(text, lang string) string {
+	text = normalizeClock12(strings.ToLower(text))
+	keywords := locale.KeywordsFor(lang)
+	to := rangePattern(keywords)
+
+	// Patrones comunes de horarios
+	patterns := []string{
+		// "10:00 - 22:00", "10:00 a 22:00", "10:00 to 22:00"
+		`(\d{1,2}:\d{2})\s*` + to + `\s*(\d{1,2}:\d{2})`,
+		// "10h - 22h" o "10h-22h"
+		`(\d{1,2})h\s*` + to + `\s*(\d{1,2})h`,
+	}
+
+	// Jornada partida: "de 10:00 a 14:00 y de 17:00 a 20:30"
+	for _, pattern := range patterns {
+		re := regexp.MustCompile(pattern)
+		if shifts := findShifts(re, text, keywords); len(shifts) > 0 {
+			return schedule.FormatIntervals(shifts)
+		}
+	}
+
+	// "abierto de lunes a sábado": devolver el contexto alrededor del match
+	if lang == "" || lang == "es" {
+		re := regexp.MustCompile(`abierto.*?(?:lunes|martes|miércoles|jueves|viernes|sábado|domingo)`)
+		if match := re.FindString(text); match != "" {
+			idx := strings.Index(text, match)
+			end := idx + len(match) + 50
+			if end > len(text) {
+				end = len(text)
+			}
+			return strings.TrimSpace(text[idx:end])
+		}
+	}
+
+	// Buscar menciones específicas de horario ("horario", "hours", "horaires"...)
+	for _, word := range keywords.Hours {
+		idx := strings.Index(text, word)
+		if idx < 0 {
+			continue
+		}
+		end := idx + len(word) + 53
+		if end > len(text) {
+			end = len(text)
+		}
+		segment := text[idx:end]
+
+		// Buscar patrón de hora en el segmento
+		re := regexp.MustCompile(`(\d{1,2}[:\.]?\d{0,2})\s*` + to + `\s*(\d{1,2}[:\.]?\d{0,2})`)
+		matches := re.FindStringSubmatch(segment)
+		if len(matches) >= 3 {
+			return fmt.Sprintf("%s - %s", normalizeTime(matches[1]), normalizeTime(matches[2]))
+		}
+	}
+
+	// Buscar "24 horas", "24 hours", "24h/24"... Se devuelve como un tramo
+	// de todo el día; el texto "abierto 24 horas" se pone al mostrarlo.
+	for _, word := range keywords.AllDay {
+		if strings.Contains(text, word) {
+			return schedule.FormatIntervals([]schedule.Interval{{Open: 0, Close: schedule.MinutesPerDay}})
+		}
+	}
+
+	return ""
+}
+
+// rangePattern devuelve una expresión que casa con el separador de un tramo
+// ("-", "a", "to", "bis"...)
+func rangePattern(keywords locale.Keywords) string {
+	alts := []string{"[-–]"}
+	for _, word := range keywords.Range {
+		alts = append(alts, regexp.QuoteMeta(word))
+	}
+	return "(?:" + strings.Join(alts, "|") + ")"
+}
+
+// findShifts devuelve el primer tramo del texto y los que le siguen unidos
+// solo por conjunciones o palabras de relleno ("y de", "and from")
+func findShifts(re *regexp.Regexp, text string, keywords locale.Keywords) []schedule.Interval {
+	var shifts []schedule.Interval
+	prevEnd := -1
+	for _, m := range re.FindAllStringSubmatchIndex(text, -1) {
+		if prevEnd >= 0 && !isShiftGap(text[prevEnd:m[0]], keywords) {
+			break
+		}
+		open, ok1 := clockMinutes(normalizeTime(text[m[2]:m[3]]))
+		close, ok2 := clockMinutes(normalizeTime(text[m[4]:m[5]]))
+		if !ok1 || !ok2 {
+			break
+		}
+		shifts = append(shifts, schedule.Interval{Open: open, Close: close})
+		prevEnd = m[1]
+	}
+	return shifts
+}
+
+// clockMinutes convierte "HH:MM" en minutos desde medianoche
+func clockMinutes(clock string) (int, bool) {
+	var h, m int
+	if _, err := fmt.Sscanf(clock, "%d:%d", &h, &m); err != nil || h > 24 || m >= 60 {
+		return 0, false
+	}
+	return h*60 + m, true
+}
+
+// isShiftGap indica si el texto entre dos tramos solo contiene separadores
+func isShiftGap(gap string, keywords locale.Keywords) bool {
+	words := strings.FieldsFunc(gap, func(r rune) bool {
+		return r == ' ' || r == ',' || r == '/' || r == '&' || r == '+'
+	})
+	for _, word := range words {
+		if !containsWord(keywords.And, word) && !containsWord(keywords.Fillers, word) {
+			return false
+		}
+	}
+	return true
+}
+
+func containsWord(words []string, word string) bool {
+	for _, w := range words {
+		if w == word {
+			return true
+		}
+	}
+	return false
+}
+
+var clock12Pattern = regexp.MustCompile(`(\d{1,2})(?:[:.](\d{2}))?\s*([ap])\.?m\b\.?`)
+
+// normalizeClock12 convierte las horas de 12 horas ("9am", "5:30 p.m.") a "HH:MM"
+func normalizeClock12(text string) string {
+	return clock12Pattern.ReplaceAllStringFunc(text, func(match string) string {
+		parts := clock12Pattern.FindStringSubmatch(match)
+		var hour, min int
+		fmt.Sscanf(parts[1], "%d", &hour)
+		fmt.Sscanf(parts[2], "%d", &min)
+		if hour < 1 || hour > 12 {
+			return match
+		}
+		hour %= 12
+		if parts[3] == "p" {
+			hour += 12
+		}
+		return fmt.Sprintf("%02d:%02d", hour, min)
+	})
+}
+
+// normalizeTime normaliza el formato de hora
+func normalizeTime(t string) string {
+	t = strings.TrimSpace(t)
+	t = strings.ReplaceAll(t, ".", ":")
+
+	// Si no tiene minutos, añadir :00
+	if !strings.Contains(t, ":") {
+		t = t + ":00"
+	}
+
+	// Asegurar formato HH:MM
+	parts := strings.Split(t, ":")
+	if len(parts) == 2 {
+		hour := parts[0]
+		min := parts[1]
+		if len(hour) == 1 {
+			hour = "0" + hour
+		}
+		if len(min) == 1 {
+			min = "0" + min
+		}
+		return hour + ":" + min
+	}
+
+	return t
+}
+
+// openAt determina si un negocio está abierto en el instante at. Con horario
+// semanal se usan sus periodos, que incluyen el turno que empezó el día
+// anterior ("vie-sáb 22:00-03:00" sigue abierto el domingo a la 01:00). Sin
+// horario semanal, o si no dice nada de ese día, se usan los tramos de hours.
+// unknown indica que no hay horario con el que decidirlo.
+func openAt(week *schedule.Week, hours string, at time.Time) (open, unknown bool) {
+	if week != nil {
+		if countdown := week.CountdownAt(at); countdown.Open || week.Day(at.Weekday()).Known {
+			return countdown.Open, false
+		}
+	}
+	if hours == "" {
+		return false, true
+	}
+	return isOpenAt(hours, at.Hour()*60+at.Minute()), false
+}
+
+// isOpenAt determina si alguno de los tramos del horario ("10:00 - 14:00,
+// 17:00 - 20:30") incluye el minuto del día indicado
+func isOpenAt(hoursInfo string, currentMins int) bool {
+	// Extraer tramos
+	re := regexp.MustCompile(`(\d{1,2}):(\d{2})\s*-\s*(\d{1,2}):(\d{2})`)
+	for _, matches := range re.FindAllStringSubmatch(hoursInfo, -1) {
+		var openH, openM, closeH, closeM int
+		fmt.Sscanf(matches[1], "%d", &openH)
+		fmt.Sscanf(matches[2], "%d", &openM)
+		fmt.Sscanf(matches[3], "%d", &closeH)
+		fmt.Sscanf(matches[4], "%d", &closeM)
+
+		openMins := openH*60 + openM
+		closeMins := closeH*60 + closeM
+		mins := currentMins
+
+		// Si cierra después de medianoche
+		if closeMins < openMins {
+			closeMins += 24 * 60
+			if mins < openMins {
+				mins += 24 * 60
+			}
+		}
+
+		if mins >= openMins && mins < closeMins {
+			return true
+		}
+	}
+
+	return false
+}
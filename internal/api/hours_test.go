@@ -0,0 +1,130 @@
+package api
+
+import (
+	"testing"
+	"time"
+)
+
+var madrid = mustLoadLocation("Europe/Madrid")
+
+func mustLoadLocation(name string) *time.Location {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		panic(err)
+	}
+	return loc
+}
+
+// hoursAt aplica a un negocio el horario de data evaluado en at
+func hoursAt(data HoursData, at time.Time) BusinessInfo {
+	q := Query{Business: "bar", City: "madrid", Country: "es", Lang: "es"}
+	info := BusinessInfo{IsUnknown: true, At: at}
+	hoursInfo, week := extractHours(data, q, at)
+	applyHours(&info, hoursInfo, week, at)
+	return info
+}
+
+func TestApplyHoursOvernightShift(t *testing.T) {
+	data := HoursData{OpeningHours: "Fr-Sa 22:00-03:00"}
+
+	tests := []struct {
+		name string
+		at   time.Time
+		open bool
+	}{
+		{"viernes antes de abrir", time.Date(2026, 10, 16, 21, 0, 0, 0, madrid), false},
+		{"viernes por la noche", time.Date(2026, 10, 16, 23, 0, 0, 0, madrid), true},
+		{"sábado de madrugada, turno del viernes", time.Date(2026, 10, 17, 1, 0, 0, 0, madrid), true},
+		{"sábado por la tarde", time.Date(2026, 10, 17, 18, 0, 0, 0, madrid), false},
+		{"domingo de madrugada, turno del sábado", time.Date(2026, 10, 18, 1, 0, 0, 0, madrid), true},
+		{"domingo tras el cierre", time.Date(2026, 10, 18, 3, 0, 0, 0, madrid), false},
+		{"jueves de madrugada", time.Date(2026, 10, 15, 1, 0, 0, 0, madrid), false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			info := hoursAt(data, tc.at)
+			if info.IsUnknown {
+				t.Fatalf("IsUnknown = true, want false")
+			}
+			if info.IsOpen != tc.open {
+				t.Errorf("IsOpen = %v, want %v", info.IsOpen, tc.open)
+			}
+			if info.Countdown == nil {
+				t.Fatalf("Countdown = nil")
+			}
+			if info.Countdown.Open != info.IsOpen {
+				t.Errorf("Countdown.Open = %v, IsOpen = %v", info.Countdown.Open, info.IsOpen)
+			}
+		})
+	}
+}
+
+func TestApplyHoursOvernightCountdown(t *testing.T) {
+	info := hoursAt(HoursData{OpeningHours: "Fr-Sa 22:00-03:00"}, time.Date(2026, 10, 18, 1, 0, 0, 0, madrid))
+	if info.Countdown == nil || info.Countdown.ClosesIn != 2*time.Hour {
+		t.Errorf("Countdown = %+v, want ClosesIn 2h", info.Countdown)
+	}
+}
+
+func TestApplyHoursSplitShift(t *testing.T) {
+	data := HoursData{Snippets: []string{"Abierto de 10:00 a 14:00 y de 17:00 a 20:30"}}
+
+	tests := []struct {
+		clock string
+		open  bool
+	}{
+		{"09:59", false},
+		{"10:00", true},
+		{"15:00", false},
+		{"17:30", true},
+		{"20:30", false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.clock, func(t *testing.T) {
+			clock, _ := time.Parse("15:04", tc.clock)
+			at := time.Date(2026, 10, 14, clock.Hour(), clock.Minute(), 0, 0, madrid)
+			info := hoursAt(data, at)
+			if info.IsUnknown || info.IsOpen != tc.open {
+				t.Errorf("IsOpen = %v (IsUnknown %v), want %v", info.IsOpen, info.IsUnknown, tc.open)
+			}
+			if info.TodayHours != "10:00-14:00, 17:00-20:30" {
+				t.Errorf("TodayHours = %q", info.TodayHours)
+			}
+		})
+	}
+}
+
+func TestApplyHoursWithoutSchedule(t *testing.T) {
+	info := hoursAt(HoursData{Snippets: []string{"El mejor bar del barrio"}}, time.Date(2026, 10, 14, 12, 0, 0, 0, madrid))
+	if !info.IsUnknown || info.IsOpen || info.Countdown != nil {
+		t.Errorf("got IsUnknown %v, IsOpen %v, Countdown %v; want unknown", info.IsUnknown, info.IsOpen, info.Countdown)
+	}
+}
+
+func TestApplyHoursAllDay(t *testing.T) {
+	for _, data := range []HoursData{
+		{OpeningHours: "24/7"},
+		{Snippets: []string{"Farmacia de guardia, abierta 24 horas"}},
+	} {
+		info := hoursAt(data, time.Date(2026, 10, 14, 23, 59, 0, 0, madrid))
+		if info.IsUnknown || !info.IsOpen {
+			t.Errorf("%+v: IsOpen = %v (IsUnknown %v), want open", data, info.IsOpen, info.IsUnknown)
+		}
+		// El texto "abierto 24 horas" se pone al mostrarlo, en el idioma de salida
+		if !info.Today.AllDay || info.TodayHours != "" {
+			t.Errorf("%+v: Today = %+v, TodayHours = %q; want AllDay y sin texto", data, info.Today, info.TodayHours)
+		}
+	}
+}
+
+func TestIsOpenAtAllDayInterval(t *testing.T) {
+	hours := extractHoursFromText("Open 24 hours", "en")
+	if hours != "00:00 - 24:00" {
+		t.Fatalf("extractHoursFromText = %q, want 00:00 - 24:00", hours)
+	}
+	for _, mins := range []int{0, 12 * 60, 23*60 + 59} {
+		if !isOpenAt(hours, mins) {
+			t.Errorf("isOpenAt(%q, %d) = false", hours, mins)
+		}
+	}
+}
@@ -0,0 +1,191 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// DefaultOverpassURL es el servidor Overpass público de OpenStreetMap
+const DefaultOverpassURL = "https://overpass-api.de/api/interpreter"
+
+// overpassResponse respuesta de Overpass con [out:json]
+type overpassResponse struct {
+	Elements []overpassElement `json:"elements"`
+}
+
+type overpassElement struct {
+	Type   string            `json:"type"`
+	ID     int64             `json:"id"`
+	Lat    float64           `json:"lat"`
+	Lon    float64           `json:"lon"`
+	Center *overpassCenter   `json:"center"`
+	Tags   map[string]string `json:"tags"`
+}
+
+type overpassCenter struct {
+	Lat float64 `json:"lat"`
+	Lon float64 `json:"lon"`
+}
+
+// osmProvider busca negocios en OpenStreetMap mediante Overpass y usa su
+// etiqueta opening_hours. No necesita API key.
+type osmProvider struct {
+	endpoint string
+	client   *Client
+}
+
+func newOSMProvider(cfg ProviderConfig) (Provider, error) {
+	endpoint := cfg.OverpassURL
+	if endpoint == "" {
+		endpoint = DefaultOverpassURL
+	}
+	return &osmProvider{endpoint: endpoint, client: cfg.Client}, nil
+}
+
+func (p *osmProvider) Name() string {
+	return "osm"
+}
+
+func (p *osmProvider) SearchPlaces(ctx context.Context, q Query, limit int) ([]PlaceResult, error) {
+	query := fmt.Sprintf(`[out:json][timeout:25];
+%s
+nwr["name"~"%s",i](area.city);
+out center tags %d;`, overpassCityArea(q), overpassString(regexp.QuoteMeta(q.Business)), limit)
+
+	resp, err := p.query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	places := make([]PlaceResult, 0, len(resp.Elements))
+	for _, el := range resp.Elements {
+		places = append(places, el.place(q.City))
+	}
+
+	return places, nil
+}
+
+// FetchHours devuelve el horario que trajo la búsqueda. Solo consulta a
+// Overpass si el lugar no lo incluye (por ejemplo, de una caché antigua).
+func (p *osmProvider) FetchHours(ctx context.Context, place PlaceResult, q Query) (HoursData, error) {
+	if place.OpeningHours != "" {
+		return HoursData{OpeningHours: place.OpeningHours}, nil
+	}
+
+	parts := strings.SplitN(place.ID, "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return HoursData{}, &APIError{
+			Type:    ErrorInvalidResponse,
+			Message: fmt.Sprintf("Identificador de OSM no válido: %q", place.ID),
+		}
+	}
+
+	query := fmt.Sprintf("[out:json][timeout:25];\n%s(%s);\nout tags;", parts[0], parts[1])
+	resp, err := p.query(ctx, query)
+	if err != nil {
+		return HoursData{}, err
+	}
+
+	for _, el := range resp.Elements {
+		if hours := el.Tags["opening_hours"]; hours != "" {
+			return HoursData{OpeningHours: hours}, nil
+		}
+	}
+
+	return HoursData{}, nil
+}
+
+// query envía una consulta Overpass QL
+func (p *osmProvider) query(ctx context.Context, query string) (*overpassResponse, error) {
+	form := url.Values{"data": {query}}
+
+	resp, err := p.client.PostForm(ctx, p.endpoint, map[string]string{"User-Agent": "pingbar"}, form, 30*time.Second)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != 200 {
+		return nil, statusError(resp)
+	}
+
+	var overpassResp overpassResponse
+	if err := json.Unmarshal(resp.Body, &overpassResp); err != nil {
+		return nil, responseError(err)
+	}
+
+	return &overpassResp, nil
+}
+
+// place convierte un elemento de OSM en un resultado de lugar
+func (el overpassElement) place(city string) PlaceResult {
+	tags := el.Tags
+	place := PlaceResult{
+		ID:          fmt.Sprintf("%s/%d", el.Type, el.ID),
+		Title:       tags["name"],
+		Address:     osmAddress(tags, city),
+		Latitude:    el.Lat,
+		Longitude:   el.Lon,
+		PhoneNumber: firstTag(tags, "phone", "contact:phone"),
+		Website:     firstTag(tags, "website", "contact:website"),
+		Category:    firstTag(tags, "amenity", "shop", "craft", "office", "tourism", "leisure"),
+
+		OpeningHours: tags["opening_hours"],
+	}
+	if el.Center != nil {
+		place.Latitude = el.Center.Lat
+		place.Longitude = el.Center.Lon
+	}
+	return place
+}
+
+// osmAddress compone la dirección a partir de las etiquetas addr:*
+func osmAddress(tags map[string]string, city string) string {
+	street := tags["addr:street"]
+	if number := tags["addr:housenumber"]; street != "" && number != "" {
+		street += ", " + number
+	}
+	town := firstTag(tags, "addr:city")
+	if town == "" && street != "" {
+		town = city
+	}
+
+	parts := make([]string, 0, 2)
+	for _, part := range []string{street, town} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+func firstTag(tags map[string]string, keys ...string) string {
+	for _, key := range keys {
+		if value := tags[key]; value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// overpassCityArea devuelve la parte de la consulta que define el área
+// .city: el término municipal con el nombre de la ciudad, dentro del país
+// de la consulta si se conoce
+func overpassCityArea(q Query) string {
+	city := fmt.Sprintf(`["name"~"^%s$",i]["boundary"="administrative"]`, overpassString(regexp.QuoteMeta(q.City)))
+	if q.Country == "" {
+		return "area" + city + "->.city;"
+	}
+	return fmt.Sprintf(`area["ISO3166-1"="%s"]["admin_level"="2"]->.country;
+rel%s(area.country);
+map_to_area->.city;`, overpassString(strings.ToUpper(q.Country)), city)
+}
+
+// overpassString escapa un texto para usarlo entre comillas en Overpass QL
+func overpassString(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s)
+}
@@ -0,0 +1,220 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// overpassStandIn simula un servidor Overpass: responde body a las
+// búsquedas y las etiquetas de tags a las consultas de un elemento
+// ("node(4);"), y guarda las consultas recibidas
+type overpassStandIn struct {
+	*httptest.Server
+	mu      sync.Mutex
+	queries []string
+}
+
+func newOverpassStandIn(t *testing.T, body string, tags map[string]map[string]string) *overpassStandIn {
+	t.Helper()
+	s := &overpassStandIn{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.FormValue("data")
+		s.mu.Lock()
+		s.queries = append(s.queries, query)
+		s.mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		for element, elementTags := range tags {
+			if strings.Contains(query, element) {
+				data, _ := json.Marshal(map[string]interface{}{
+					"elements": []interface{}{map[string]interface{}{"tags": elementTags}},
+				})
+				w.Write(data)
+				return
+			}
+		}
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *overpassStandIn) Queries() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.queries...)
+}
+
+func newTestOSMProvider(t *testing.T, url string) Provider {
+	t.Helper()
+	provider, err := NewProvider("osm", ProviderConfig{OverpassURL: url})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return provider
+}
+
+const overpassBars = `{"elements": [
+	{"type": "node", "id": 1, "lat": 40.41, "lon": -3.70, "tags": {"name": "Bar Pepe", "amenity": "bar", "addr:street": "Calle Mayor", "addr:housenumber": "1", "opening_hours": "Mo-Sa 12:00-24:00"}},
+	{"type": "way", "id": 2, "center": {"lat": 40.42, "lon": -3.71}, "tags": {"name": "Bar Luis", "amenity": "bar", "opening_hours": "Fr-Sa 22:00-03:00"}},
+	{"type": "node", "id": 3, "lat": 40.43, "lon": -3.72, "tags": {"name": "Bar Ana", "amenity": "bar", "opening_hours": "24/7"}},
+	{"type": "node", "id": 4, "lat": 40.44, "lon": -3.73, "tags": {"name": "Bar Sin Horario", "amenity": "bar"}}
+]}`
+
+func TestOSMSearchUsesHoursFromSearch(t *testing.T) {
+	server := newOverpassStandIn(t, overpassBars, map[string]map[string]string{
+		"node(4);": {"name": "Bar Sin Horario"},
+	})
+	provider := newTestOSMProvider(t, server.URL)
+
+	// Domingo 18 de octubre a la 01:00
+	at := time.Date(2026, 10, 18, 1, 0, 0, 0, madrid)
+	q := Query{Business: "bar", City: "Madrid", Country: "es"}
+	results, err := Search(context.Background(), provider, q, 10, SearchOptions{NoCache: true, HoursLookups: 1, At: at})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Los negocios con opening_hours en la búsqueda no se vuelven a consultar
+	// ni gastan consultas; la única que queda es para el que no lo trae
+	queries := server.Queries()
+	if len(queries) != 2 || !strings.Contains(queries[1], "node(4);") {
+		t.Errorf("consultas a Overpass:\n%s\nwant la búsqueda y node(4)", strings.Join(queries, "\n---\n"))
+	}
+
+	want := []struct {
+		name    string
+		open    bool
+		unknown bool
+	}{
+		{"Bar Pepe", false, false},
+		{"Bar Luis", true, false}, // Turno del sábado hasta las 03:00
+		{"Bar Ana", true, false},
+		{"Bar Sin Horario", false, true},
+	}
+	if len(results) != len(want) {
+		t.Fatalf("%d resultados, want %d", len(results), len(want))
+	}
+	for i, w := range want {
+		r := results[i]
+		if r.Name != w.name || r.IsOpen != w.open || r.IsUnknown != w.unknown {
+			t.Errorf("resultado %d = %s open=%v unknown=%v, want %s open=%v unknown=%v",
+				i, r.Name, r.IsOpen, r.IsUnknown, w.name, w.open, w.unknown)
+		}
+	}
+	if results[0].Address != "Calle Mayor, 1, Madrid" {
+		t.Errorf("Address = %q", results[0].Address)
+	}
+}
+
+func TestOSMSearchQueryArea(t *testing.T) {
+	server := newOverpassStandIn(t, `{"elements": []}`, nil)
+	provider := newTestOSMProvider(t, server.URL)
+
+	if _, err := provider.SearchPlaces(context.Background(), Query{Business: `Bar "Pepe"`, City: "Las Palmas", Country: "es"}, 5); err != nil {
+		t.Fatal(err)
+	}
+	query := server.Queries()[0]
+	for _, want := range []string{
+		`area["ISO3166-1"="ES"]["admin_level"="2"]->.country;`,
+		`rel["name"~"^Las Palmas$",i]["boundary"="administrative"](area.country);`,
+		`nwr["name"~"Bar \"Pepe\"",i](area.city);`,
+		`out center tags 5;`,
+	} {
+		if !strings.Contains(query, want) {
+			t.Errorf("la consulta no contiene %s:\n%s", want, query)
+		}
+	}
+}
+
+func TestOSMFetchHours(t *testing.T) {
+	server := newOverpassStandIn(t, `{"elements": []}`, map[string]map[string]string{
+		"node(7);": {"opening_hours": "Mo-Fr 09:00-14:00"},
+	})
+	provider := newTestOSMProvider(t, server.URL)
+	ctx := context.Background()
+
+	// Con el horario de la búsqueda no se consulta a Overpass
+	data, err := provider.FetchHours(ctx, PlaceResult{ID: "node/7", OpeningHours: "24/7"}, Query{})
+	if err != nil || data.OpeningHours != "24/7" || len(server.Queries()) != 0 {
+		t.Errorf("FetchHours con horario = %+v, %v; %d consultas", data, err, len(server.Queries()))
+	}
+
+	// Sin él (por ejemplo, un lugar de una caché antigua), sí
+	data, err = provider.FetchHours(ctx, PlaceResult{ID: "node/7"}, Query{})
+	if err != nil || data.OpeningHours != "Mo-Fr 09:00-14:00" {
+		t.Errorf("FetchHours = %+v, %v", data, err)
+	}
+	if queries := server.Queries(); len(queries) != 1 || !strings.Contains(queries[0], "node(7);") {
+		t.Errorf("consultas = %q", queries)
+	}
+
+	_, err = provider.FetchHours(ctx, PlaceResult{ID: "7"}, Query{})
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || !errors.Is(err, ErrParse) {
+		t.Errorf("FetchHours con un identificador no válido = %v, want un APIError ErrParse", err)
+	}
+}
+
+func TestOSMErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		body     string
+		wantType ErrorType
+		want     error
+	}{
+		// Overpass responde 429 sin Retry-After cuando no quedan turnos libres:
+		// es un límite de frecuencia, no créditos agotados
+		{"sin turnos libres", http.StatusTooManyRequests, "", ErrorRateLimited, ErrQuota},
+		{"caído", http.StatusGatewayTimeout, "", ErrorServer, ErrNetwork},
+		{"respuesta no válida", http.StatusOK, "<html>", ErrorInvalidResponse, ErrParse},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tc.status)
+				w.Write([]byte(tc.body))
+			}))
+			defer server.Close()
+
+			_, err := newTestOSMProvider(t, server.URL).SearchPlaces(context.Background(), Query{Business: "bar", City: "Madrid"}, 5)
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) || apiErr.Type != tc.wantType || !errors.Is(err, tc.want) {
+				t.Errorf("err = %v, want %s (%v)", err, tc.wantType, tc.want)
+			}
+		})
+	}
+}
+
+func TestOSMRetriesRateLimit(t *testing.T) {
+	var hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&hits, 1) <= 2 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(overpassBars))
+	}))
+	defer server.Close()
+
+	provider, err := NewProvider("osm", ProviderConfig{OverpassURL: server.URL, Retry: RetryPolicy{Retries: 2, MaxWait: time.Millisecond}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	places, err := provider.SearchPlaces(context.Background(), Query{Business: "bar", City: "Madrid"}, 5)
+	if err != nil || len(places) != 4 {
+		t.Errorf("SearchPlaces = %d lugares, %v; want 4 tras dos 429", len(places), err)
+	}
+	if hits != 3 {
+		t.Errorf("%d peticiones, want 3", hits)
+	}
+}
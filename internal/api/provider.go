@@ -0,0 +1,114 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/686f6c61/pingbar/internal/schedule"
+)
+
+// DefaultProvider es el proveedor usado si no se configura otro
+const DefaultProvider = "serper"
+
+// PlaceResult representa un resultado de lugar de un proveedor
+type PlaceResult struct {
+	ID          string  `json:"cid,omitempty"`
+	Title       string  `json:"title"`
+	Address     string  `json:"address"`
+	Latitude    float64 `json:"latitude,omitempty"`
+	Longitude   float64 `json:"longitude,omitempty"`
+	Rating      float64 `json:"rating"`
+	RatingCount int     `json:"ratingCount"`
+	Category    string  `json:"category"`
+	PhoneNumber string  `json:"phoneNumber"`
+	Website     string  `json:"website"`
+	// OpeningHours es el horario en formato opening_hours de OpenStreetMap si
+	// la búsqueda ya lo trae; así no hace falta consultarlo con FetchHours
+	OpeningHours string `json:"opening_hours,omitempty"`
+}
+
+// HoursData es la información de horario que devuelve un proveedor.
+// Los proveedores con horarios estructurados rellenan Schedule u
+// OpeningHours; el resto devuelve textos libres de los que se extrae el horario.
+type HoursData struct {
+	Snippets     []string       `json:"snippets,omitempty"`
+	Schedule     *schedule.Week `json:"schedule,omitempty"`
+	OpeningHours string         `json:"opening_hours,omitempty"` // Formato opening_hours de OpenStreetMap
+}
+
+// Query describe una búsqueda de negocios
+type Query struct {
+	Business string
+	City     string
+	Country  string // Código ISO 3166-1 alfa-2 ("es", "pt", "fr")
+	Lang     string // Idioma de búsqueda ("es", "en", "fr")
+}
+
+// Provider es un origen de negocios y horarios
+type Provider interface {
+	// Name identifica al proveedor en la configuración y en la caché
+	Name() string
+	// SearchPlaces busca hasta limit negocios que encajen con la consulta
+	SearchPlaces(ctx context.Context, q Query, limit int) ([]PlaceResult, error)
+	// FetchHours obtiene el horario de un negocio devuelto por SearchPlaces
+	FetchHours(ctx context.Context, place PlaceResult, q Query) (HoursData, error)
+}
+
+// ProviderConfig contiene los ajustes necesarios para crear un proveedor
+type ProviderConfig struct {
+	APIKey      string
+	BaseURL     string            // URL base de Serper; vacía para DefaultSerperURL
+	OverpassURL string            // Servidor Overpass; vacío para DefaultOverpassURL
+	Transport   http.RoundTripper // Transporte HTTP; nil para el de por defecto
+	Retry       RetryPolicy       // Reintentos ante fallos transitorios
+	Client      *Client           // Cliente HTTP; si es nil se crea con Transport y Retry
+}
+
+// ProviderFactory crea un proveedor a partir de la configuración
+type ProviderFactory func(cfg ProviderConfig) (Provider, error)
+
+var providers = map[string]ProviderFactory{
+	"serper": newSerperProvider,
+	"osm":    newOSMProvider,
+}
+
+// RegisterProvider añade un proveedor al registro
+func RegisterProvider(name string, factory ProviderFactory) {
+	providers[name] = factory
+}
+
+// Providers devuelve los nombres de los proveedores registrados
+func Providers() []string {
+	names := make([]string, 0, len(providers))
+	for name := range providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// NewProvider crea el proveedor con el nombre indicado
+func NewProvider(name string, cfg ProviderConfig) (Provider, error) {
+	if name == "" {
+		name = DefaultProvider
+	}
+
+	factory, ok := providers[name]
+	if !ok {
+		return nil, &APIError{Type: ErrorUnknown, Message: fmt.Sprintf("Proveedor desconocido: %s", name)}
+	}
+
+	return factory(cfg.withClient())
+}
+
+// withClient completa la configuración con un cliente HTTP creado a partir
+// de Transport y Retry si no trae uno
+func (cfg ProviderConfig) withClient() ProviderConfig {
+	if cfg.Client == nil {
+		cfg.Client = NewClient(cfg.Transport)
+		cfg.Client.Retry = cfg.Retry
+	}
+	return cfg
+}
@@ -0,0 +1,80 @@
+package api
+
+import (
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// DefaultRetries es el número de reintentos por defecto tras un fallo transitorio
+const DefaultRetries = 2
+
+// DefaultRetryMaxWait es la espera máxima por defecto antes de un reintento
+const DefaultRetryMaxWait = 10 * time.Second
+
+// retryBaseDelay es la espera antes del primer reintento sin Retry-After
+const retryBaseDelay = 500 * time.Millisecond
+
+// RetryPolicy controla los reintentos de las peticiones que fallan de forma
+// transitoria. El valor cero no reintenta.
+type RetryPolicy struct {
+	Retries int           // Reintentos tras el primer intento
+	MaxWait time.Duration // Espera máxima entre intentos; 0 para DefaultRetryMaxWait
+}
+
+// transient indica si merece la pena repetir una petición: límites de
+// frecuencia, errores del servidor (5xx) y errores de red o de plazo. Los
+// códigos de error se traducen con statusErr, la función de cada API.
+func transient(resp *Response, err error, statusErr func(*Response) error) bool {
+	if err == nil {
+		if resp.StatusCode < 400 {
+			return false
+		}
+		err = statusErr(resp)
+	}
+	apiErr, ok := err.(*APIError)
+	return ok && apiErr.Temporary()
+}
+
+// delay devuelve la espera antes del reintento siguiente a attempt. Respeta
+// Retry-After si la respuesta lo trae; si pide esperar más de MaxWait no se
+// reintenta. Sin Retry-After usa espera exponencial con variación aleatoria.
+func (p RetryPolicy) delay(attempt int, resp *Response) (time.Duration, bool) {
+	maxWait := p.MaxWait
+	if maxWait <= 0 {
+		maxWait = DefaultRetryMaxWait
+	}
+
+	if resp != nil {
+		if wait, ok := retryAfter(resp.Header.Get("Retry-After")); ok {
+			return wait, wait <= maxWait
+		}
+	}
+
+	wait := retryBaseDelay << uint(attempt)
+	if wait > maxWait || wait <= 0 {
+		wait = maxWait
+	}
+	// Entre la mitad y el total, para que varios clientes no reintenten a la vez
+	wait = wait/2 + time.Duration(rand.Int63n(int64(wait/2)+1))
+	return wait, true
+}
+
+// retryAfter interpreta la cabecera Retry-After en segundos o como fecha HTTP
+func retryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(value); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		wait := time.Until(at)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+	return 0, false
+}
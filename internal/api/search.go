@@ -0,0 +1,372 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/686f6c61/pingbar/internal/cache"
+	"github.com/686f6c61/pingbar/internal/holidays"
+	"github.com/686f6c61/pingbar/internal/locale"
+	"github.com/686f6c61/pingbar/internal/schedule"
+)
+
+// BusinessInfo representa la información procesada de un negocio
+type BusinessInfo struct {
+	Name        string
+	Address     string
+	Rating      float64
+	RatingCount int
+	Category    string
+	Phone       string
+	Website     string
+	IsOpen      bool
+	IsUnknown   bool
+	TodayHours  string              // Tramos de hoy o el horario en texto libre
+	Today       schedule.Day        // Hoy en el horario semanal: cerrado, 24 horas o tramos
+	HoursInfo   string              // Información de horario extraída
+	Schedule    *schedule.Week      // Horario semanal, si se pudo interpretar
+	Tomorrow    *Forecast           // Previsión para mañana (--tomorrow)
+	Countdown   *schedule.Countdown // Tiempo hasta el próximo cambio de estado
+	Holiday     string              // Festivo de hoy en la ciudad; el estado es incierto
+	At          time.Time           // Instante en el que se evalúa el estado, en la zona horaria del negocio
+
+	// HoursChecked indica si se obtuvo respuesta sobre el horario, de la caché
+	// o del proveedor. Si es true y no hay horario, el negocio no lo publica.
+	HoursChecked bool
+	// HoursError es el fallo de la consulta del horario, si la hubo
+	HoursError error
+}
+
+// DefaultHoursLookups es el número de horarios que se consultan al proveedor
+// en cada búsqueda si no se indica otro
+const DefaultHoursLookups = 3
+
+// DefaultHoursConcurrency es el número de consultas de horario simultáneas por defecto
+const DefaultHoursConcurrency = 4
+
+// SearchOptions controla el uso de la caché local y de créditos en Search
+type SearchOptions struct {
+	NoCache          bool // No leer ni escribir la caché
+	Refresh          bool // Ignorar la caché existente y sobrescribirla
+	HoursLookups     int  // Horarios a consultar al proveedor (los de la caché no cuentan)
+	HoursConcurrency int  // Consultas de horario simultáneas
+
+	// At es el instante en el que se evalúa si los negocios están abiertos.
+	// Sin valor se usa la hora actual.
+	At time.Time
+	// Location es la zona horaria en la que se evalúa el horario de todos los
+	// negocios (--tz). Sin valor, se deduce de la ubicación de cada uno.
+	Location *time.Location
+}
+
+// Search busca negocios con el proveedor indicado y extrae sus horarios.
+// Los lugares y horarios se guardan en la caché local para no gastar
+// créditos en consultas repetidas.
+//
+// ctx limita toda la búsqueda. Si se cancela, Search devuelve un APIError
+// "cancelled"; si vence su plazo durante las consultas de horario, devuelve
+// los lugares con los horarios obtenidos hasta entonces.
+func Search(ctx context.Context, provider Provider, q Query, limit int, opts SearchOptions) ([]BusinessInfo, error) {
+	if limit <= 0 {
+		limit = 10
+	}
+	q = q.withDefaults()
+	at := opts.At
+	if at.IsZero() {
+		at = time.Now()
+	}
+
+	// Los aciertos y fallos de la caché se guardan una vez por búsqueda
+	defer cache.FlushStats()
+
+	// Paso 1: Buscar lugares
+	places, err := searchPlaces(ctx, provider, q, limit, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]BusinessInfo, 0, len(places))
+
+	// Los festivos pueden cambiar el horario habitual
+	holiday, isHoliday := holidays.Lookup(q.Country, q.City, at.In(Location(q, "", opts.Location)))
+
+	for _, place := range places {
+		info := BusinessInfo{
+			Name:        place.Title,
+			Address:     place.Address,
+			Rating:      place.Rating,
+			RatingCount: place.RatingCount,
+			Category:    place.Category,
+			Phone:       place.PhoneNumber,
+			Website:     place.Website,
+			IsUnknown:   true,
+			At:          at.In(Location(q, place.Address, opts.Location)),
+		}
+		if isHoliday {
+			info.Holiday = holiday.Name
+		}
+		results = append(results, info)
+	}
+
+	// Paso 2: Extraer horarios: los que trae la búsqueda, los de la caché y
+	// después los del proveedor
+	pending := make([]int, 0, len(places))
+	for i, place := range places {
+		data, ok := HoursData{OpeningHours: place.OpeningHours}, place.OpeningHours != ""
+		if !ok {
+			data, ok = cachedHours(provider, place, q, opts)
+		}
+		if ok {
+			results[i].HoursChecked = true
+			hoursInfo, week := extractHours(data, q, results[i].At)
+			applyHours(&results[i], hoursInfo, week, results[i].At)
+		} else {
+			pending = append(pending, i)
+		}
+	}
+
+	// Solo se consultan los primeros horarios que faltan (ahorrar créditos)
+	lookups := opts.HoursLookups
+	if lookups <= 0 {
+		lookups = DefaultHoursLookups
+	}
+	if len(pending) > lookups {
+		pending = pending[:lookups]
+	}
+	fetchAllHours(ctx, provider, places, results, pending, q, opts)
+
+	if ctx.Err() == context.Canceled {
+		return nil, contextError(ctx)
+	}
+
+	return results, nil
+}
+
+// fetchAllHours consulta al proveedor los horarios de los lugares indicados
+// con un número limitado de consultas simultáneas. Cada consulta escribe en
+// su propia posición de results, así que el orden se conserva. El estado se
+// evalúa en el instante At de cada resultado.
+func fetchAllHours(ctx context.Context, provider Provider, places []PlaceResult, results []BusinessInfo, indexes []int, q Query, opts SearchOptions) {
+	workers := opts.HoursConcurrency
+	if workers <= 0 {
+		workers = DefaultHoursConcurrency
+	}
+	if workers > len(indexes) {
+		workers = len(indexes)
+	}
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				data, err := searchHours(ctx, provider, places[i], q, opts)
+				if err != nil {
+					results[i].HoursError = err
+					continue
+				}
+				results[i].HoursChecked = true
+				hoursInfo, week := extractHours(data, q, results[i].At)
+				applyHours(&results[i], hoursInfo, week, results[i].At)
+			}
+		}()
+	}
+
+dispatch:
+	for _, i := range indexes {
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+			break dispatch
+		}
+	}
+	close(jobs)
+	wg.Wait()
+}
+
+// applyHours rellena el horario de un negocio y calcula si está abierto en
+// el instante at
+func applyHours(info *BusinessInfo, hoursInfo string, week *schedule.Week, at time.Time) {
+	info.HoursInfo = hoursInfo
+	info.Schedule = week
+	info.TodayHours = hoursInfo
+
+	if week != nil {
+		info.Today = week.Day(at.Weekday())
+		if info.Today.Known {
+			info.TodayHours = info.Today.Hours()
+		}
+		if info.HoursInfo == "" {
+			info.HoursInfo = info.TodayHours
+		}
+	}
+
+	// Sin horario semanal, el horario en texto libre se asume diario
+	if week == nil && hoursInfo != "" {
+		week = schedule.Parse(hoursInfo)
+	}
+
+	if open, unknown := openAt(week, info.TodayHours, at); !unknown {
+		info.IsUnknown = false
+		info.IsOpen = open
+	}
+	if week != nil && !info.IsUnknown {
+		countdown := week.CountdownAt(at)
+		if countdown.Open == info.IsOpen {
+			info.Countdown = &countdown
+		}
+	}
+}
+
+// Location devuelve la zona horaria en la que se evalúa el horario de un
+// negocio: override si se indica (--tz) o la de su dirección, su ciudad o su
+// país. Si no se conoce, la del sistema.
+func Location(q Query, address string, override *time.Location) *time.Location {
+	if override != nil {
+		return override
+	}
+	if name := locale.TimeZone(q.Country, q.City, address); name != "" {
+		if loc, err := time.LoadLocation(name); err == nil {
+			return loc
+		}
+	}
+	return time.Local
+}
+
+// withDefaults completa el país y el idioma de búsqueda
+func (q Query) withDefaults() Query {
+	country := locale.LookupCountry(q.Country)
+	q.Country = country.Code
+	if q.Lang == "" {
+		q.Lang = country.Language
+	}
+	return q
+}
+
+// searchPlaces busca lugares con el proveedor, pasando por la caché
+func searchPlaces(ctx context.Context, provider Provider, q Query, limit int, opts SearchOptions) ([]PlaceResult, error) {
+	key := placesCacheKey(provider, q, limit)
+	cityKey := normalizeCacheKey(q.City)
+
+	if !opts.NoCache && !opts.Refresh {
+		if data, ok := cache.Get(key, cityKey); ok {
+			var places []PlaceResult
+			if err := json.Unmarshal(data, &places); err == nil {
+				return places, nil
+			}
+		}
+	}
+
+	places, err := provider.SearchPlaces(ctx, q, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	if !opts.NoCache {
+		if data, err := json.Marshal(places); err == nil {
+			cache.Set(key, cityKey, data, cache.DefaultTTL)
+		}
+	}
+
+	return places, nil
+}
+
+// cachedHours obtiene el horario de un lugar de la caché, sin consultar al proveedor
+func cachedHours(provider Provider, place PlaceResult, q Query, opts SearchOptions) (HoursData, bool) {
+	if opts.NoCache || opts.Refresh {
+		return HoursData{}, false
+	}
+
+	data, ok := cache.Get(hoursCacheKey(provider, q, place.Title), normalizeCacheKey(q.City))
+	if !ok {
+		return HoursData{}, false
+	}
+
+	var hours HoursData
+	if err := json.Unmarshal(data, &hours); err != nil {
+		return HoursData{}, false
+	}
+	return hours, true
+}
+
+// searchHours obtiene el horario de un lugar con el proveedor y lo guarda en la caché
+func searchHours(ctx context.Context, provider Provider, place PlaceResult, q Query, opts SearchOptions) (HoursData, error) {
+	key := hoursCacheKey(provider, q, place.Title)
+	cityKey := normalizeCacheKey(q.City)
+
+	hours, err := provider.FetchHours(ctx, place, q)
+	if err != nil {
+		return HoursData{}, err
+	}
+
+	if !opts.NoCache {
+		if data, err := json.Marshal(hours); err == nil {
+			cache.Set(key, cityKey, data, cache.DefaultTTL)
+		}
+	}
+
+	return hours, nil
+}
+
+// placesCacheKey identifica una búsqueda de lugares en la caché.
+// El límite forma parte de la clave porque cambia el número de resultados pedidos.
+func placesCacheKey(provider Provider, q Query, limit int) string {
+	return fmt.Sprintf("%s|%s-%s|places|%s|%d", provider.Name(), q.Country, q.Lang, normalizeCacheKey(q.Business), limit)
+}
+
+// hoursCacheKey identifica el horario de un negocio en la caché
+func hoursCacheKey(provider Provider, q Query, businessName string) string {
+	return fmt.Sprintf("%s|%s-%s|hours|%s", provider.Name(), q.Country, q.Lang, normalizeCacheKey(businessName))
+}
+
+func normalizeCacheKey(s string) string {
+	return strings.ToLower(strings.TrimSpace(s))
+}
+
+// Forecast es el estado previsto de un negocio en un momento concreto
+type Forecast struct {
+	At        time.Time
+	Hours     string       // Tramos del día de At o el horario en texto libre
+	Day       schedule.Day // El día de At en el horario semanal
+	IsOpen    bool
+	IsUnknown bool
+}
+
+// ForecastAt predice si el negocio estará abierto en el instante indicado,
+// visto en la zona horaria del negocio. Tiene en cuenta los turnos que
+// empezaron el día anterior y terminan después de medianoche.
+func ForecastAt(info BusinessInfo, at time.Time) Forecast {
+	if !info.At.IsZero() {
+		at = at.In(info.At.Location())
+	}
+	forecast := Forecast{At: at, IsUnknown: true}
+
+	if info.Schedule != nil {
+		forecast.Day = info.Schedule.Day(at.Weekday())
+	}
+	forecast.Hours = hoursOn(info, at.Weekday())
+
+	// Sin horario semanal, el horario en texto libre se asume diario
+	week := info.Schedule
+	if week == nil && info.HoursInfo != "" {
+		week = schedule.Parse(info.HoursInfo)
+	}
+	forecast.IsOpen, forecast.IsUnknown = openAt(week, forecast.Hours, at)
+
+	return forecast
+}
+
+// hoursOn devuelve los tramos de un día de la semana. Sin horario semanal,
+// se asume que el horario en texto libre se repite todos los días.
+func hoursOn(info BusinessInfo, day time.Weekday) string {
+	if info.Schedule != nil {
+		return info.Schedule.Day(day).Hours()
+	}
+	return info.HoursInfo
+}
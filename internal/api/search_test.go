@@ -0,0 +1,52 @@
+package api
+
+import (
+	"testing"
+	"time"
+)
+
+func TestForecastAtOvernightShift(t *testing.T) {
+	// Buscado el viernes por la tarde; watch y --at miran otros momentos
+	info := hoursAt(HoursData{OpeningHours: "Fr-Sa 22:00-03:00"}, time.Date(2026, 10, 16, 18, 0, 0, 0, madrid))
+
+	tests := []struct {
+		name   string
+		at     time.Time
+		open   bool
+		hours  string
+		closed bool // El día de At está cerrado en el horario semanal
+	}{
+		{"viernes por la noche", time.Date(2026, 10, 16, 23, 0, 0, 0, madrid), true, "22:00 - 03:00", false},
+		{"domingo de madrugada", time.Date(2026, 10, 18, 1, 0, 0, 0, madrid), true, "", true},
+		{"domingo tras el cierre", time.Date(2026, 10, 18, 3, 0, 0, 0, madrid), false, "", true},
+		{"domingo de madrugada, en UTC", time.Date(2026, 10, 17, 23, 30, 0, 0, time.UTC), true, "", true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			forecast := ForecastAt(info, tc.at)
+			if forecast.IsUnknown {
+				t.Fatalf("IsUnknown = true, want false")
+			}
+			if forecast.IsOpen != tc.open {
+				t.Errorf("IsOpen = %v, want %v", forecast.IsOpen, tc.open)
+			}
+			if forecast.Hours != tc.hours || !forecast.Day.Known || forecast.Day.Closed != tc.closed {
+				t.Errorf("Hours = %q, Day = %+v; want %q, Closed %v", forecast.Hours, forecast.Day, tc.hours, tc.closed)
+			}
+			if forecast.At.Location() != madrid {
+				t.Errorf("At en %s, want Europe/Madrid", forecast.At.Location())
+			}
+		})
+	}
+}
+
+func TestForecastAtFreeTextHours(t *testing.T) {
+	info := hoursAt(HoursData{Snippets: []string{"Horario: 10:00 - 22:00"}}, time.Date(2026, 10, 14, 12, 0, 0, 0, madrid))
+
+	if f := ForecastAt(info, time.Date(2026, 10, 17, 21, 0, 0, 0, madrid)); f.IsUnknown || !f.IsOpen {
+		t.Errorf("sábado 21:00: IsOpen %v, IsUnknown %v; want open", f.IsOpen, f.IsUnknown)
+	}
+	if f := ForecastAt(info, time.Date(2026, 10, 17, 23, 0, 0, 0, madrid)); f.IsUnknown || f.IsOpen {
+		t.Errorf("sábado 23:00: IsOpen %v, IsUnknown %v; want closed", f.IsOpen, f.IsUnknown)
+	}
+}
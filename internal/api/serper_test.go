@@ -0,0 +1,362 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// serperStandIn simula la API de Serper: cada petición recibe la respuesta
+// de handler, y hits cuenta las peticiones
+type serperStandIn struct {
+	*httptest.Server
+	hits int32
+}
+
+func newSerperStandIn(t *testing.T, handler http.HandlerFunc) *serperStandIn {
+	t.Helper()
+	s := &serperStandIn{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&s.hits, 1)
+		handler(w, r)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *serperStandIn) Hits() int {
+	return int(atomic.LoadInt32(&s.hits))
+}
+
+// respond devuelve un handler que responde siempre con status, cabeceras y body
+func respond(status int, body string, headers ...string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		for i := 0; i+1 < len(headers); i += 2 {
+			w.Header().Set(headers[i], headers[i+1])
+		}
+		w.WriteHeader(status)
+		io.WriteString(w, body)
+	}
+}
+
+func newTestSerperProvider(t *testing.T, cfg ProviderConfig) Provider {
+	t.Helper()
+	if cfg.APIKey == "" {
+		cfg.APIKey = "test-key"
+	}
+	provider, err := NewProvider("serper", cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return provider
+}
+
+var serperQuery = Query{Business: "bar pepe", City: "Madrid", Country: "es", Lang: "es"}
+
+// placesJSON devuelve una respuesta de /places con un lugar por dirección
+func placesJSON(addresses ...string) json.RawMessage {
+	places := make([]PlaceResult, 0, len(addresses))
+	for i, address := range addresses {
+		places = append(places, PlaceResult{Title: fmt.Sprintf("Bar %d", i+1), Address: address})
+	}
+	data, _ := json.Marshal(SerperPlacesResponse{Places: places})
+	return data
+}
+
+func TestParsePlacesLimit(t *testing.T) {
+	tests := []struct {
+		name      string
+		addresses []string
+		limit     int
+		want      []string
+	}{
+		{
+			name:      "filtra por ciudad",
+			addresses: []string{"Calle Mayor 1, Madrid", "Calle Real 2, Getafe", "Gran Vía 3, Madrid"},
+			limit:     5,
+			want:      []string{"Bar 1", "Bar 3"},
+		},
+		{
+			name:      "filtra por ciudad y limita",
+			addresses: []string{"Calle Mayor 1, Madrid", "Gran Vía 3, Madrid", "Sol 4, Madrid"},
+			limit:     2,
+			want:      []string{"Bar 1", "Bar 2"},
+		},
+		{
+			name:      "sin coincidencias con la ciudad también limita",
+			addresses: []string{"Calle Real 2, Getafe", "Plaza 5, Leganés", "Avenida 6, Móstoles", "Calle 7, Alcorcón"},
+			limit:     2,
+			want:      []string{"Bar 1", "Bar 2"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			places, err := parsePlaces(placesJSON(tc.addresses...), "Madrid", tc.limit)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := make([]string, 0, len(places))
+			for _, p := range places {
+				got = append(got, p.Title)
+			}
+			if strings.Join(got, ",") != strings.Join(tc.want, ",") {
+				t.Errorf("parsePlaces = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSerperSearchPlacesResponses(t *testing.T) {
+	tests := []struct {
+		name     string
+		handler  http.HandlerFunc
+		wantType ErrorType // Vacío si no hay error
+		wantErr  error
+		places   int
+	}{
+		{
+			name:    "200 con resultados",
+			handler: respond(http.StatusOK, string(placesJSON("Calle Mayor 1, Madrid", "Gran Vía 3, Madrid"))),
+			places:  2,
+		},
+		{
+			name:    "200 sin resultados",
+			handler: respond(http.StatusOK, `{"places": []}`),
+		},
+		{
+			name:    "200 sin la lista de lugares",
+			handler: respond(http.StatusOK, `{"searchParameters": {"q": "bar pepe Madrid"}}`),
+		},
+		{
+			name:     "401",
+			handler:  respond(http.StatusUnauthorized, `{"message": "Unauthorized."}`),
+			wantType: ErrorInvalidKey,
+			wantErr:  ErrInvalidKey,
+		},
+		{
+			name:     "429 con Retry-After",
+			handler:  respond(http.StatusTooManyRequests, "", "Retry-After", "60"),
+			wantType: ErrorRateLimited,
+			wantErr:  ErrQuota,
+		},
+		{
+			name:     "429 sin Retry-After",
+			handler:  respond(http.StatusTooManyRequests, `{"message": "Not enough credits"}`),
+			wantType: ErrorLimitReached,
+			wantErr:  ErrQuota,
+		},
+		{
+			name:     "500",
+			handler:  respond(http.StatusInternalServerError, ""),
+			wantType: ErrorServer,
+			wantErr:  ErrNetwork,
+		},
+		{
+			name:     "503",
+			handler:  respond(http.StatusServiceUnavailable, ""),
+			wantType: ErrorServer,
+			wantErr:  ErrNetwork,
+		},
+		{
+			name:     "JSON mal formado",
+			handler:  respond(http.StatusOK, `{"places": [{"title": "Bar`),
+			wantType: ErrorInvalidResponse,
+			wantErr:  ErrParse,
+		},
+		{
+			name:     "JSON con otro formato",
+			handler:  respond(http.StatusOK, `{"places": "ninguno"}`),
+			wantType: ErrorInvalidResponse,
+			wantErr:  ErrParse,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			server := newSerperStandIn(t, tc.handler)
+			provider := newTestSerperProvider(t, ProviderConfig{BaseURL: server.URL})
+
+			places, err := provider.SearchPlaces(context.Background(), serperQuery, 5)
+			if tc.wantType == "" {
+				if err != nil {
+					t.Fatalf("err = %v", err)
+				}
+				if len(places) != tc.places {
+					t.Errorf("%d lugares, want %d", len(places), tc.places)
+				}
+				return
+			}
+
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("err = %v (%T), want *APIError", err, err)
+			}
+			if apiErr.Type != tc.wantType {
+				t.Errorf("Type = %s, want %s", apiErr.Type, tc.wantType)
+			}
+			if !errors.Is(err, tc.wantErr) {
+				t.Errorf("errors.Is(%v, %v) = false", err, tc.wantErr)
+			}
+			if server.Hits() != 1 {
+				t.Errorf("%d peticiones sin reintentos, want 1", server.Hits())
+			}
+		})
+	}
+}
+
+func TestSerperRequest(t *testing.T) {
+	var got struct {
+		path, key string
+		body      map[string]interface{}
+	}
+	server := newSerperStandIn(t, func(w http.ResponseWriter, r *http.Request) {
+		got.path = r.URL.Path
+		got.key = r.Header.Get("X-API-KEY")
+		json.NewDecoder(r.Body).Decode(&got.body)
+		io.WriteString(w, `{"places": []}`)
+	})
+	provider := newTestSerperProvider(t, ProviderConfig{APIKey: "clave", BaseURL: server.URL + "/"})
+
+	if _, err := provider.SearchPlaces(context.Background(), serperQuery, 5); err != nil {
+		t.Fatal(err)
+	}
+	if got.path != "/places" || got.key != "clave" {
+		t.Errorf("petición a %s con clave %q", got.path, got.key)
+	}
+	if got.body["q"] != "bar pepe Madrid" || got.body["gl"] != "es" || got.body["hl"] != "es" || got.body["num"] != float64(10) {
+		t.Errorf("cuerpo = %v", got.body)
+	}
+}
+
+func TestSerperFetchHours(t *testing.T) {
+	server := newSerperStandIn(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/search" {
+			http.NotFound(w, r)
+			return
+		}
+		io.WriteString(w, `{"organic": [
+			{"title": "Bar Pepe", "snippet": "Horario: lunes a viernes de 9:00 a 14:00"},
+			{"title": "Bar Pepe - Madrid", "snippet": "Tapas y raciones"}
+		]}`)
+	})
+	provider := newTestSerperProvider(t, ProviderConfig{BaseURL: server.URL})
+
+	data, err := provider.FetchHours(context.Background(), PlaceResult{Title: "Bar Pepe"}, serperQuery)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data.Snippets) != 2 || !strings.Contains(data.Snippets[0], "9:00 a 14:00") {
+		t.Errorf("Snippets = %q", data.Snippets)
+	}
+}
+
+func TestSerperFetchHoursCreditsExhausted(t *testing.T) {
+	server := newSerperStandIn(t, respond(http.StatusTooManyRequests, `{"message": "Not enough credits"}`))
+	provider := newTestSerperProvider(t, ProviderConfig{BaseURL: server.URL, Retry: RetryPolicy{Retries: 2, MaxWait: time.Millisecond}})
+
+	_, err := provider.FetchHours(context.Background(), PlaceResult{Title: "Bar Pepe"}, serperQuery)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Type != ErrorLimitReached {
+		t.Errorf("err = %v, want %s", err, ErrorLimitReached)
+	}
+	if server.Hits() != 1 {
+		t.Errorf("%d peticiones, want 1: los créditos agotados no se reintentan", server.Hits())
+	}
+}
+
+func TestSerperTransport(t *testing.T) {
+	server := newSerperStandIn(t, respond(http.StatusOK, `{"places": []}`))
+	var calls int32
+	transport := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		atomic.AddInt32(&calls, 1)
+		return http.DefaultTransport.RoundTrip(r)
+	})
+	provider := newTestSerperProvider(t, ProviderConfig{BaseURL: server.URL, Transport: transport})
+
+	if _, err := provider.SearchPlaces(context.Background(), serperQuery, 5); err != nil {
+		t.Fatal(err)
+	}
+	if calls != 1 || server.Hits() != 1 {
+		t.Errorf("%d peticiones por el transporte, %d en el servidor; want 1", calls, server.Hits())
+	}
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func TestSerperRetries(t *testing.T) {
+	fast := RetryPolicy{Retries: 2, MaxWait: time.Millisecond}
+
+	tests := []struct {
+		name     string
+		retry    RetryPolicy
+		handler  http.HandlerFunc
+		wantHits int
+		wantErr  error // nil si la búsqueda acaba bien
+	}{
+		{"sin reintentos", RetryPolicy{}, respond(http.StatusServiceUnavailable, ""), 1, ErrNetwork},
+		{"5xx agota los reintentos", fast, respond(http.StatusServiceUnavailable, ""), 3, ErrNetwork},
+		{"un reintento", RetryPolicy{Retries: 1, MaxWait: time.Millisecond}, respond(http.StatusBadGateway, ""), 2, ErrNetwork},
+		{"429 con Retry-After se reintenta", fast, respond(http.StatusTooManyRequests, "", "Retry-After", "0"), 3, ErrQuota},
+		{"429 sin Retry-After no se reintenta", fast, respond(http.StatusTooManyRequests, ""), 1, ErrQuota},
+		{"Retry-After mayor que la espera máxima", fast, respond(http.StatusTooManyRequests, "", "Retry-After", "60"), 1, ErrQuota},
+		{"401 no se reintenta", fast, respond(http.StatusUnauthorized, ""), 1, ErrInvalidKey},
+		{"se recupera tras un 503", fast, failFirst(1, http.StatusServiceUnavailable), 2, nil},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			server := newSerperStandIn(t, tc.handler)
+			provider := newTestSerperProvider(t, ProviderConfig{BaseURL: server.URL, Retry: tc.retry})
+
+			_, err := provider.SearchPlaces(context.Background(), serperQuery, 5)
+			if tc.wantErr == nil && err != nil {
+				t.Errorf("err = %v", err)
+			}
+			if tc.wantErr != nil && !errors.Is(err, tc.wantErr) {
+				t.Errorf("err = %v, want %v", err, tc.wantErr)
+			}
+			if server.Hits() != tc.wantHits {
+				t.Errorf("%d peticiones, want %d", server.Hits(), tc.wantHits)
+			}
+		})
+	}
+}
+
+// failFirst responde status a las n primeras peticiones y después un 200 sin resultados
+func failFirst(n int32, status int) http.HandlerFunc {
+	var count int32
+	return func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&count, 1) <= n {
+			w.WriteHeader(status)
+			return
+		}
+		io.WriteString(w, `{"places": []}`)
+	}
+}
+
+func TestGetRawResponse(t *testing.T) {
+	server := newSerperStandIn(t, failFirst(1, http.StatusServiceUnavailable))
+	cfg := ProviderConfig{APIKey: "clave", BaseURL: server.URL, Retry: RetryPolicy{Retries: 1, MaxWait: time.Millisecond}}
+
+	data, err := GetRawResponse(context.Background(), cfg, serperQuery, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"places": []}` || server.Hits() != 2 {
+		t.Errorf("GetRawResponse = %s tras %d peticiones, want la respuesta de la URL configurada tras un reintento", data, server.Hits())
+	}
+
+	if _, err := GetRawResponse(context.Background(), ProviderConfig{BaseURL: server.URL}, serperQuery, 5); !errors.Is(err, ErrNoAPIKey) {
+		t.Errorf("sin API key: err = %v, want ErrNoAPIKey", err)
+	}
+}
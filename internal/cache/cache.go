@@ -4,8 +4,11 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/686f6c61/pingbar/internal/config"
@@ -18,9 +21,25 @@ type CacheEntry struct {
 	TTLHours  int             `json:"ttl_hours"`
 }
 
+// Stats contiene los contadores de aciertos y fallos de la caché
+type Stats struct {
+	Hits   int `json:"hits"`
+	Misses int `json:"misses"`
+}
+
 // DefaultTTL es el tiempo de vida por defecto de la caché (24 horas)
 const DefaultTTL = 24
 
+// statsFileName no lleva extensión .json para no contarse como entrada.
+// Cada línea son los aciertos y fallos de una búsqueda: "3 1".
+const statsFileName = "stats"
+
+// pending son los aciertos y fallos de este proceso aún no guardados
+var (
+	statsMu sync.Mutex
+	pending Stats
+)
+
 func generateKey(business, city string) string {
 	h := sha256.New()
 	h.Write([]byte(business + "|" + city))
@@ -38,20 +57,24 @@ func Get(business, city string) (json.RawMessage, bool) {
 
 	data, err := os.ReadFile(cacheFile)
 	if err != nil {
+		recordLookup(false)
 		return nil, false
 	}
 
 	var entry CacheEntry
 	if err := json.Unmarshal(data, &entry); err != nil {
+		recordLookup(false)
 		return nil, false
 	}
 
 	expirationTime := entry.Timestamp.Add(time.Duration(entry.TTLHours) * time.Hour)
 	if time.Now().After(expirationTime) {
 		os.Remove(cacheFile)
+		recordLookup(false)
 		return nil, false
 	}
 
+	recordLookup(true)
 	return entry.Data, true
 }
 
@@ -99,6 +122,8 @@ func Clear() error {
 		}
 	}
 
+	os.Remove(filepath.Join(cacheDir, statsFileName))
+
 	return nil
 }
 
@@ -120,3 +145,101 @@ func Size() int {
 
 	return count
 }
+
+// GetStats devuelve los aciertos y fallos acumulados desde la última
+// limpieza, incluidos los de este proceso que aún no se han guardado
+func GetStats() (Stats, error) {
+	stats, err := readStats()
+
+	statsMu.Lock()
+	stats.Hits += pending.Hits
+	stats.Misses += pending.Misses
+	statsMu.Unlock()
+
+	return stats, err
+}
+
+// recordLookup suma un acierto o un fallo a los contadores del proceso.
+// Se guardan en disco con FlushStats.
+func recordLookup(hit bool) {
+	statsMu.Lock()
+	defer statsMu.Unlock()
+
+	if hit {
+		pending.Hits++
+	} else {
+		pending.Misses++
+	}
+}
+
+// FlushStats guarda los aciertos y fallos pendientes del proceso. Cada
+// llamada añade una línea "aciertos fallos" al final del archivo, en una
+// sola escritura con O_APPEND, de modo que varios procesos a la vez (cron,
+// el prompt de la shell) no se pisan los contadores.
+func FlushStats() error {
+	statsMu.Lock()
+	stats := pending
+	pending = Stats{}
+	statsMu.Unlock()
+
+	if stats.Hits == 0 && stats.Misses == 0 {
+		return nil
+	}
+
+	err := appendStats(stats)
+	if err != nil {
+		// Se conservan para el siguiente intento
+		statsMu.Lock()
+		pending.Hits += stats.Hits
+		pending.Misses += stats.Misses
+		statsMu.Unlock()
+	}
+	return err
+}
+
+func appendStats(stats Stats) error {
+	if err := os.MkdirAll(config.CacheDir(), 0755); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(filepath.Join(config.CacheDir(), statsFileName), os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(file, "%d %d\n", stats.Hits, stats.Misses); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// readStats suma las líneas del archivo de estadísticas. Un archivo que no
+// existe son cero aciertos y fallos; las líneas que no se entienden (por
+// ejemplo, una escritura cortada) se saltan y se informa del error.
+func readStats() (Stats, error) {
+	var stats Stats
+	data, err := os.ReadFile(filepath.Join(config.CacheDir(), statsFileName))
+	if os.IsNotExist(err) {
+		return stats, nil
+	}
+	if err != nil {
+		return stats, err
+	}
+
+	var bad int
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		if line == "" {
+			continue
+		}
+		var hits, misses int
+		if n, _ := fmt.Sscanf(line, "%d %d", &hits, &misses); n != 2 || hits < 0 || misses < 0 {
+			bad++
+			continue
+		}
+		stats.Hits += hits
+		stats.Misses += misses
+	}
+	if bad > 0 {
+		return stats, fmt.Errorf("%d líneas no válidas en %s", bad, statsFileName)
+	}
+	return stats, nil
+}
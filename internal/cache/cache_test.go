@@ -0,0 +1,128 @@
+package cache
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/686f6c61/pingbar/internal/config"
+)
+
+// useTempCache apunta la caché a un directorio temporal
+func useTempCache(t *testing.T) string {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("LOCALAPPDATA", os.Getenv("HOME"))
+	statsMu.Lock()
+	pending = Stats{}
+	statsMu.Unlock()
+	return config.CacheDir()
+}
+
+func TestGetSet(t *testing.T) {
+	useTempCache(t)
+
+	if _, ok := Get("bar pepe", "madrid"); ok {
+		t.Fatal("Get en una caché vacía = ok")
+	}
+	if err := Set("bar pepe", "madrid", json.RawMessage(`{"a":1}`), 1); err != nil {
+		t.Fatal(err)
+	}
+	data, ok := Get("bar pepe", "madrid")
+	if !ok || string(data) != `{"a":1}` {
+		t.Errorf("Get = %s, %v", data, ok)
+	}
+	if Size() != 1 {
+		t.Errorf("Size = %d, want 1", Size())
+	}
+}
+
+func TestStatsFlushOncePerSearch(t *testing.T) {
+	dir := useTempCache(t)
+	Set("bar pepe", "madrid", json.RawMessage(`{}`), 1)
+
+	Get("bar pepe", "madrid")
+	Get("bar luis", "madrid")
+	Get("bar ana", "madrid")
+
+	// Antes de guardarlos, los contadores del proceso ya se ven
+	stats, err := GetStats()
+	if err != nil || stats != (Stats{Hits: 1, Misses: 2}) {
+		t.Errorf("GetStats = %+v, %v; want 1 acierto y 2 fallos", stats, err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, statsFileName)); !os.IsNotExist(err) {
+		t.Errorf("las consultas escriben el archivo de estadísticas: %v", err)
+	}
+
+	if err := FlushStats(); err != nil {
+		t.Fatal(err)
+	}
+	if err := FlushStats(); err != nil { // Sin nada pendiente no escribe
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(filepath.Join(dir, statsFileName))
+	if string(data) != "1 2\n" {
+		t.Errorf("archivo de estadísticas = %q, want %q", data, "1 2\n")
+	}
+	if stats, err := GetStats(); err != nil || stats != (Stats{Hits: 1, Misses: 2}) {
+		t.Errorf("GetStats tras guardar = %+v, %v", stats, err)
+	}
+}
+
+func TestStatsConcurrentWriters(t *testing.T) {
+	dir := useTempCache(t)
+
+	// Cada escritura es una línea añadida al final: los procesos que guardan
+	// a la vez no se pisan los contadores
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := appendStats(Stats{Hits: 2, Misses: 1}); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	stats, err := readStats()
+	if err != nil || stats != (Stats{Hits: 100, Misses: 50}) {
+		t.Errorf("readStats = %+v, %v; want 100 aciertos y 50 fallos", stats, err)
+	}
+	data, _ := os.ReadFile(filepath.Join(dir, statsFileName))
+	if lines := strings.Count(string(data), "\n"); lines != 50 {
+		t.Errorf("%d líneas, want 50", lines)
+	}
+}
+
+func TestStatsInvalidLines(t *testing.T) {
+	dir := useTempCache(t)
+	os.MkdirAll(dir, 0755)
+	os.WriteFile(filepath.Join(dir, statsFileName), []byte("3 1\n{\"hits\":5}\n2 0\n4"), 0644)
+
+	stats, err := GetStats()
+	if stats != (Stats{Hits: 5, Misses: 1}) {
+		t.Errorf("GetStats = %+v, want las líneas válidas (5 aciertos, 1 fallo)", stats)
+	}
+	if err == nil {
+		t.Error("err = nil con líneas no válidas")
+	}
+}
+
+func TestClearRemovesStats(t *testing.T) {
+	useTempCache(t)
+	Set("bar pepe", "madrid", json.RawMessage(`{}`), 1)
+	Get("bar pepe", "madrid")
+	FlushStats()
+
+	if err := Clear(); err != nil {
+		t.Fatal(err)
+	}
+	if stats, err := GetStats(); err != nil || stats != (Stats{}) || Size() != 0 {
+		t.Errorf("tras Clear: GetStats = %+v, %v; Size = %d", stats, err, Size())
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"path/filepath"
 	"runtime"
 	"strings"
+	"time"
 )
 
 // Config representa la configuración de pingbar
@@ -16,8 +17,26 @@ type Config struct {
 	DefaultCity  string
 	Color        string
 	DefaultLimit int
+	Provider     string
+	OverpassURL  string
+	Country      string
+	SearchLang   string
+	HoursLookups int
+	HoursWorkers int
+	APIBaseURL   string
+	Retries      int
+	RetryMaxWait time.Duration
+
+	// Avisos de pingbar notify
+	NotifyCommand string
+	NotifyFIFO    string
+	NotifyWebhook string
+	NotifyDBus    string
 }
 
+// APIBaseURLEnv es la variable de entorno que sustituye a la clave api-base-url
+const APIBaseURLEnv = "PINGBAR_API_BASE_URL"
+
 // ConfigDir devuelve el directorio de configuración según el SO
 func ConfigDir() string {
 	if runtime.GOOS == "windows" {
@@ -47,11 +66,19 @@ func Load() (*Config, error) {
 		Lang:         "es",
 		Color:        "auto",
 		DefaultLimit: 10,
+		Provider:     "serper",
+		Country:      "es",
+		HoursLookups: 3,
+		HoursWorkers: 4,
+		Retries:      2,
+		RetryMaxWait: 10 * time.Second,
+		NotifyDBus:   "auto",
 	}
 
 	file, err := os.Open(ConfigFile())
 	if err != nil {
 		if os.IsNotExist(err) {
+			applyEnv(cfg)
 			return cfg, nil
 		}
 		return nil, err
@@ -88,20 +115,80 @@ func Load() (*Config, error) {
 			if limit > 0 && limit <= 50 {
 				cfg.DefaultLimit = limit
 			}
+		case "provider":
+			cfg.Provider = value
+		case "overpass-url":
+			cfg.OverpassURL = value
+		case "country":
+			cfg.Country = strings.ToLower(value)
+		case "search-lang":
+			cfg.SearchLang = strings.ToLower(value)
+		case "hours-lookups":
+			var n int
+			fmt.Sscanf(value, "%d", &n)
+			if n > 0 && n <= 50 {
+				cfg.HoursLookups = n
+			}
+		case "hours-concurrency":
+			var n int
+			fmt.Sscanf(value, "%d", &n)
+			if n > 0 && n <= 10 {
+				cfg.HoursWorkers = n
+			}
+		case "api-base-url":
+			cfg.APIBaseURL = value
+		case "retries":
+			var n int
+			if _, err := fmt.Sscanf(value, "%d", &n); err == nil && n >= 0 && n <= 10 {
+				cfg.Retries = n
+			}
+		case "retry-max-wait":
+			if d, err := time.ParseDuration(value); err == nil && d > 0 {
+				cfg.RetryMaxWait = d
+			}
+		case "notify-command":
+			cfg.NotifyCommand = value
+		case "notify-fifo":
+			cfg.NotifyFIFO = value
+		case "notify-webhook":
+			cfg.NotifyWebhook = value
+		case "notify-dbus":
+			cfg.NotifyDBus = value
 		}
 	}
 
+	applyEnv(cfg)
 	return cfg, scanner.Err()
 }
 
+// applyEnv aplica las variables de entorno, que tienen prioridad sobre el archivo
+func applyEnv(cfg *Config) {
+	if v := os.Getenv(APIBaseURLEnv); v != "" {
+		cfg.APIBaseURL = v
+	}
+}
+
 // Set establece un valor de configuración
 func Set(key, value string) error {
 	validKeys := map[string]bool{
-		"apikey":        true,
-		"lang":          true,
-		"default-city":  true,
-		"color":         true,
-		"default-limit": true,
+		"apikey":            true,
+		"lang":              true,
+		"default-city":      true,
+		"color":             true,
+		"default-limit":     true,
+		"provider":          true,
+		"overpass-url":      true,
+		"country":           true,
+		"search-lang":       true,
+		"hours-lookups":     true,
+		"hours-concurrency": true,
+		"api-base-url":      true,
+		"retries":           true,
+		"retry-max-wait":    true,
+		"notify-command":    true,
+		"notify-fifo":       true,
+		"notify-webhook":    true,
+		"notify-dbus":       true,
 	}
 
 	if !validKeys[key] {
@@ -123,6 +210,54 @@ func Set(key, value string) error {
 		if err != nil || limit < 1 || limit > 50 {
 			return fmt.Errorf("límite no válido: %s (debe ser entre 1 y 50)", value)
 		}
+	case "provider":
+		if value == "" {
+			return fmt.Errorf("proveedor no válido: %s", value)
+		}
+	case "overpass-url", "api-base-url":
+		if !strings.HasPrefix(value, "http://") && !strings.HasPrefix(value, "https://") {
+			return fmt.Errorf("URL no válida: %s (debe empezar por http:// o https://)", value)
+		}
+	case "country":
+		if !isLetterCode(value) {
+			return fmt.Errorf("país no válido: %s (usa un código de dos letras como 'es' o 'pt')", value)
+		}
+	case "search-lang":
+		if !isLetterCode(value) {
+			return fmt.Errorf("idioma de búsqueda no válido: %s (usa un código de dos letras como 'es' o 'fr')", value)
+		}
+	case "hours-lookups":
+		var n int
+		_, err := fmt.Sscanf(value, "%d", &n)
+		if err != nil || n < 1 || n > 50 {
+			return fmt.Errorf("número de horarios no válido: %s (debe ser entre 1 y 50)", value)
+		}
+	case "hours-concurrency":
+		var n int
+		_, err := fmt.Sscanf(value, "%d", &n)
+		if err != nil || n < 1 || n > 10 {
+			return fmt.Errorf("concurrencia no válida: %s (debe ser entre 1 y 10)", value)
+		}
+	case "retries":
+		var n int
+		_, err := fmt.Sscanf(value, "%d", &n)
+		if err != nil || n < 0 || n > 10 {
+			return fmt.Errorf("número de reintentos no válido: %s (debe ser entre 0 y 10)", value)
+		}
+	case "retry-max-wait":
+		d, err := time.ParseDuration(value)
+		if err != nil || d <= 0 {
+			return fmt.Errorf("espera no válida: %s (usa una duración como 10s o 1m)", value)
+		}
+	case "notify-webhook":
+		// Vacío desactiva el webhook
+		if value != "" && !strings.HasPrefix(value, "http://") && !strings.HasPrefix(value, "https://") {
+			return fmt.Errorf("URL no válida: %s (debe empezar por http:// o https://)", value)
+		}
+	case "notify-dbus":
+		if value != "on" && value != "off" && value != "auto" {
+			return fmt.Errorf("valor de notify-dbus no válido: %s (usa 'on', 'off' o 'auto')", value)
+		}
 	}
 
 	if err := os.MkdirAll(ConfigDir(), 0755); err != nil {
@@ -179,6 +314,32 @@ func Get(key string) (string, error) {
 		return cfg.Color, nil
 	case "default-limit":
 		return fmt.Sprintf("%d", cfg.DefaultLimit), nil
+	case "provider":
+		return cfg.Provider, nil
+	case "overpass-url":
+		return cfg.OverpassURL, nil
+	case "country":
+		return cfg.Country, nil
+	case "search-lang":
+		return cfg.SearchLang, nil
+	case "hours-lookups":
+		return fmt.Sprintf("%d", cfg.HoursLookups), nil
+	case "hours-concurrency":
+		return fmt.Sprintf("%d", cfg.HoursWorkers), nil
+	case "api-base-url":
+		return cfg.APIBaseURL, nil
+	case "retries":
+		return fmt.Sprintf("%d", cfg.Retries), nil
+	case "retry-max-wait":
+		return cfg.RetryMaxWait.String(), nil
+	case "notify-command":
+		return cfg.NotifyCommand, nil
+	case "notify-fifo":
+		return cfg.NotifyFIFO, nil
+	case "notify-webhook":
+		return cfg.NotifyWebhook, nil
+	case "notify-dbus":
+		return cfg.NotifyDBus, nil
 	default:
 		return "", fmt.Errorf("clave de configuración no válida: %s", key)
 	}
@@ -197,10 +358,36 @@ func List() (map[string]string, error) {
 	result["default-city"] = cfg.DefaultCity
 	result["color"] = cfg.Color
 	result["default-limit"] = fmt.Sprintf("%d", cfg.DefaultLimit)
+	result["provider"] = cfg.Provider
+	result["overpass-url"] = cfg.OverpassURL
+	result["country"] = cfg.Country
+	result["search-lang"] = cfg.SearchLang
+	result["hours-lookups"] = fmt.Sprintf("%d", cfg.HoursLookups)
+	result["hours-concurrency"] = fmt.Sprintf("%d", cfg.HoursWorkers)
+	result["api-base-url"] = cfg.APIBaseURL
+	result["retries"] = fmt.Sprintf("%d", cfg.Retries)
+	result["retry-max-wait"] = cfg.RetryMaxWait.String()
+	result["notify-command"] = cfg.NotifyCommand
+	result["notify-fifo"] = cfg.NotifyFIFO
+	result["notify-webhook"] = cfg.NotifyWebhook
+	result["notify-dbus"] = cfg.NotifyDBus
 
 	return result, nil
 }
 
+// isLetterCode indica si el valor es un código de dos letras ("es", "PT")
+func isLetterCode(value string) bool {
+	if len(value) != 2 {
+		return false
+	}
+	for _, r := range strings.ToLower(value) {
+		if r < 'a' || r > 'z' {
+			return false
+		}
+	}
+	return true
+}
+
 func maskAPIKey(key string) string {
 	if key == "" {
 		return "(no configurada)"
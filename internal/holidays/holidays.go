@@ -0,0 +1,185 @@
+package holidays
+
+import (
+	_ "embed"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/686f6c61/pingbar/internal/config"
+)
+
+// Holiday representa un día festivo
+type Holiday struct {
+	Name   string
+	Region string // Vacío para festivos nacionales
+}
+
+// rule define un festivo por fecha fija ("MM-DD" o "AAAA-MM-DD")
+// o por días desde el Domingo de Resurrección
+type rule struct {
+	Date   string `json:"date,omitempty"`
+	Easter *int   `json:"easter,omitempty"`
+	Name   string `json:"name"`
+}
+
+type region struct {
+	Name     string   `json:"name"`
+	Cities   []string `json:"cities"`
+	Holidays []rule   `json:"holidays"`
+}
+
+type calendar struct {
+	National []rule            `json:"national"`
+	Regions  map[string]region `json:"regions"`
+}
+
+// Country es el país que cubre el calendario incluido
+const Country = "es"
+
+//go:embed es.json
+var embeddedData []byte
+
+var (
+	loadOnce sync.Once
+	loaded   *calendar
+)
+
+// LocalFile devuelve la ruta del calendario local que amplía el incluido
+func LocalFile() string {
+	return filepath.Join(config.ConfigDir(), "holidays.json")
+}
+
+// Lookup indica si la fecha es festivo nacional o autonómico en la ciudad.
+// El calendario solo cubre España; en otros países nunca hay festivo.
+func Lookup(country, city string, date time.Time) (Holiday, bool) {
+	if country != "" && !strings.EqualFold(country, Country) {
+		return Holiday{}, false
+	}
+
+	cal := load()
+
+	for _, r := range cal.National {
+		if r.matches(date) {
+			return Holiday{Name: r.Name}, true
+		}
+	}
+
+	key, reg, ok := cal.regionFor(city)
+	if !ok {
+		return Holiday{}, false
+	}
+	for _, r := range reg.Holidays {
+		if r.matches(date) {
+			return Holiday{Name: r.Name, Region: key}, true
+		}
+	}
+
+	return Holiday{}, false
+}
+
+// load combina el calendario incluido en el binario con el archivo local, si existe
+func load() *calendar {
+	loadOnce.Do(func() {
+		cal := &calendar{}
+		if err := json.Unmarshal(embeddedData, cal); err != nil {
+			cal = &calendar{}
+		}
+		if cal.Regions == nil {
+			cal.Regions = make(map[string]region)
+		}
+
+		if data, err := os.ReadFile(LocalFile()); err == nil {
+			var local calendar
+			if err := json.Unmarshal(data, &local); err == nil {
+				cal.merge(&local)
+			}
+		}
+
+		loaded = cal
+	})
+	return loaded
+}
+
+// merge añade los festivos, regiones y ciudades de otro calendario
+func (c *calendar) merge(other *calendar) {
+	c.National = append(c.National, other.National...)
+
+	for key, reg := range other.Regions {
+		existing, ok := c.Regions[key]
+		if !ok {
+			c.Regions[key] = reg
+			continue
+		}
+		if reg.Name != "" {
+			existing.Name = reg.Name
+		}
+		existing.Cities = append(existing.Cities, reg.Cities...)
+		existing.Holidays = append(existing.Holidays, reg.Holidays...)
+		c.Regions[key] = existing
+	}
+}
+
+// regionFor busca la comunidad autónoma de una ciudad (o por su nombre)
+func (c *calendar) regionFor(city string) (string, region, bool) {
+	city = normalize(city)
+
+	if reg, ok := c.Regions[city]; ok {
+		return city, reg, true
+	}
+	for key, reg := range c.Regions {
+		for _, name := range reg.Cities {
+			if normalize(name) == city {
+				return key, reg, true
+			}
+		}
+	}
+
+	return "", region{}, false
+}
+
+func (r rule) matches(date time.Time) bool {
+	if r.Easter != nil {
+		easter := easterSunday(date.Year())
+		target := easter.AddDate(0, 0, *r.Easter)
+		return target.Month() == date.Month() && target.Day() == date.Day()
+	}
+
+	switch len(r.Date) {
+	case len("01-02"):
+		return r.Date == date.Format("01-02")
+	case len("2006-01-02"):
+		return r.Date == date.Format("2006-01-02")
+	}
+	return false
+}
+
+// easterSunday calcula el Domingo de Resurrección (algoritmo de Butcher)
+func easterSunday(year int) time.Time {
+	a := year % 19
+	b := year / 100
+	c := year % 100
+	d := b / 4
+	e := b % 4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i := c / 4
+	k := c % 4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+}
+
+var accentReplacer = strings.NewReplacer(
+	"á", "a", "é", "e", "í", "i", "ó", "o", "ú", "u", "ü", "u", "ñ", "n", "à", "a", "è", "e", "ò", "o",
+)
+
+func normalize(s string) string {
+	return accentReplacer.Replace(strings.ToLower(strings.TrimSpace(s)))
+}
@@ -0,0 +1,146 @@
+package holidays
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+// useCalendar vuelve a cargar el calendario con HOME en un directorio
+// temporal y, si local no está vacío, con ese holidays.json local
+func useCalendar(t *testing.T, local string) {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("APPDATA", os.Getenv("HOME"))
+	if local != "" {
+		if err := os.MkdirAll(filepath.Dir(LocalFile()), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(LocalFile(), []byte(local), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	loadOnce = sync.Once{}
+	loaded = nil
+	t.Cleanup(func() {
+		loadOnce = sync.Once{}
+		loaded = nil
+	})
+}
+
+func date(s string) time.Time {
+	d, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+func TestEasterSunday(t *testing.T) {
+	tests := map[int]string{
+		2000: "2000-04-23",
+		2019: "2019-04-21",
+		2024: "2024-03-31",
+		2025: "2025-04-20",
+		2026: "2026-04-05",
+		2027: "2027-03-28",
+		2038: "2038-04-25", // La más tardía posible
+		2285: "2285-03-22", // La más temprana posible
+	}
+	for year, want := range tests {
+		if got := easterSunday(year).Format("2006-01-02"); got != want {
+			t.Errorf("easterSunday(%d) = %s, want %s", year, got, want)
+		}
+	}
+}
+
+func TestLookup(t *testing.T) {
+	useCalendar(t, "")
+
+	tests := []struct {
+		name    string
+		country string
+		city    string
+		date    string
+		want    string // Nombre del festivo, vacío si no lo es
+		region  string
+	}{
+		{"Viernes Santo 2024", "es", "madrid", "2024-03-29", "Viernes Santo", ""},
+		{"Viernes Santo 2025", "es", "madrid", "2025-04-18", "Viernes Santo", ""},
+		{"Viernes Santo 2026", "es", "madrid", "2026-04-03", "Viernes Santo", ""},
+		{"Viernes Santo 2027", "es", "madrid", "2027-03-26", "Viernes Santo", ""},
+		{"Jueves Santo 2024", "es", "madrid", "2024-03-28", "Jueves Santo", "madrid"},
+		{"Jueves Santo 2025", "es", "Málaga", "2025-04-17", "Jueves Santo", "andalucia"},
+		{"Jueves Santo 2026", "es", "Sevilla", "2026-04-02", "Jueves Santo", "andalucia"},
+		{"Jueves Santo 2027", "es", "madrid", "2027-03-25", "Jueves Santo", "madrid"},
+		{"Jueves Santo no es festivo en Cataluña", "es", "barcelona", "2025-04-17", "", ""},
+		{"Lunes de Pascua en Cataluña", "es", "Barcelona", "2025-04-21", "Lunes de Pascua", "cataluna"},
+		{"Lunes de Pascua no es festivo en Madrid", "es", "madrid", "2025-04-21", "", ""},
+		{"festivo autonómico de fecha fija", "es", "madrid", "2026-05-02", "Fiesta de la Comunidad de Madrid", "madrid"},
+		{"región por su clave", "es", "cataluna", "2026-09-11", "Diada Nacional de Cataluña", "cataluna"},
+		{"festivo nacional de fecha fija", "es", "Madrid", "2026-10-12", "Fiesta Nacional de España", ""},
+		{"día laborable", "es", "madrid", "2026-10-14", "", ""},
+		{"sin país se asume España", "", "madrid", "2026-12-25", "Navidad", ""},
+		{"el país no distingue mayúsculas", "ES", "madrid", "2026-12-25", "Navidad", ""},
+		{"ciudad fuera de la tabla: festivo nacional", "es", "Villarriba", "2025-04-18", "Viernes Santo", ""},
+		{"ciudad fuera de la tabla: sin festivo autonómico", "es", "Villarriba", "2025-04-17", "", ""},
+		{"sin ciudad", "es", "", "2026-05-02", "", ""},
+		{"otro país", "fr", "paris", "2026-12-25", "", ""},
+		{"otro país con ciudad española", "pt", "madrid", "2025-04-18", "", ""},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			h, ok := Lookup(tc.country, tc.city, date(tc.date))
+			if ok != (tc.want != "") || h.Name != tc.want || h.Region != tc.region {
+				t.Errorf("Lookup(%q, %q, %s) = %+v, %v; want %q en %q", tc.country, tc.city, tc.date, h, ok, tc.want, tc.region)
+			}
+		})
+	}
+}
+
+func TestLookupLocalFile(t *testing.T) {
+	useCalendar(t, `{
+		"national": [{"date": "2026-10-16", "name": "Puente"}],
+		"regions": {
+			"madrid": {"cities": ["tres cantos"], "holidays": [{"date": "05-15", "name": "San Isidro"}]},
+			"andorra-ficticia": {"name": "Ficticia", "cities": ["villarriba"], "holidays": [{"easter": 50, "name": "Lunes de Pentecostés"}]}
+		}
+	}`)
+
+	tests := []struct {
+		city   string
+		date   string
+		want   string
+		region string
+	}{
+		// Un festivo nacional con año solo vale ese año
+		{"madrid", "2026-10-16", "Puente", ""},
+		{"madrid", "2027-10-16", "", ""},
+		// Se añaden a la región existente sin perder los del calendario incluido
+		{"madrid", "2026-05-15", "San Isidro", "madrid"},
+		{"Tres Cantos", "2026-05-02", "Fiesta de la Comunidad de Madrid", "madrid"},
+		{"Getafe", "2026-05-15", "San Isidro", "madrid"},
+		// Región nueva
+		{"Villarriba", "2026-05-25", "Lunes de Pentecostés", "andorra-ficticia"},
+		{"Villarriba", "2026-04-02", "", ""},
+	}
+	for _, tc := range tests {
+		h, ok := Lookup("es", tc.city, date(tc.date))
+		if ok != (tc.want != "") || h.Name != tc.want || h.Region != tc.region {
+			t.Errorf("Lookup(%q, %s) = %+v, %v; want %q en %q", tc.city, tc.date, h, ok, tc.want, tc.region)
+		}
+	}
+	if name := load().Regions["madrid"].Name; name != "Comunidad de Madrid" {
+		t.Errorf("el archivo local sin nombre cambia el de la región a %q", name)
+	}
+}
+
+func TestLookupInvalidLocalFile(t *testing.T) {
+	useCalendar(t, `{"national": [`)
+
+	if h, ok := Lookup("es", "madrid", date("2026-12-25")); !ok || h.Name != "Navidad" {
+		t.Errorf("con un holidays.json no válido Lookup = %+v, %v; want Navidad", h, ok)
+	}
+}
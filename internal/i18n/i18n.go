@@ -17,9 +17,35 @@ type Messages struct {
 	Tomorrow        string
 	ClosesIn        string
 	ClosedAgo       string
+	OpensIn         string
 	Holiday         string
+	HolidayAt       string
+	AtHeader        string
+	LocalTime       string
+	BatchStatus     string
+	BatchBusiness   string
+	BatchCity       string
+	BatchAt         string
+	BatchName       string
+	BatchHours      string
+	BatchNoResults  string
+	BatchError      string
+	BatchSummary    string
+	ColumnStatus    string
+	ColumnName      string
+	ColumnAddress   string
+	ColumnHours     string
+	ColumnRating    string
+	ColumnPhone     string
 	SpecialHours    string
 	NoSchedule      string
+	HoursLookupFailed string
+	HoursNotChecked string
+	HoursNotPublished string
+	WeekSchedule    string
+	ClosedDay       string
+	OpenAllDay      string
+	AtTime          string
 	NotFound        string
 	Found           string
 	MoreResults     string
@@ -33,6 +59,12 @@ type Messages struct {
 	ErrorInvalidKey string
 	ErrorNoConnection string
 	ErrorLimitReached string
+	ErrorTimeout    string
+	ErrorCancelled  string
+	ErrorRateLimited string
+	ErrorServer     string
+	ErrorInvalidResponse string
+	ErrorInvalidRequest string
 	ConfigSet       string
 	ConfigGet       string
 	CacheCleared    string
@@ -40,6 +72,8 @@ type Messages struct {
 	UninstallDone   string
 	DeleteConfig    string
 	DeleteCache     string
+	NotifyTitle     string
+	NotifyMessage   string
 	Yes             string
 	No              string
 }
@@ -53,9 +87,35 @@ var translations = map[Lang]Messages{
 		Tomorrow:        "Mañana",
 		ClosesIn:        "cierra en %s",
 		ClosedAgo:       "cerró hace %s",
+		OpensIn:         "abre en %s",
 		Holiday:         "Hoy es festivo, puede que no esté abierto",
+		HolidayAt:       "Ese día es festivo, puede que no esté abierto",
+		AtHeader:        "Estado previsto el %s %s a las %s",
+		LocalTime:       "hora local %s, %s",
+		BatchStatus:     "ESTADO",
+		BatchBusiness:   "NEGOCIO",
+		BatchCity:       "CIUDAD",
+		BatchAt:         "MOMENTO",
+		BatchName:       "RESULTADO",
+		BatchHours:      "HORARIO",
+		BatchNoResults:  "Sin resultados",
+		BatchError:      "ERROR",
+		BatchSummary:    "Abiertos: %d · Cerrados: %d · Sin horario: %d · Errores: %d",
+		ColumnStatus:    "Estado",
+		ColumnName:      "Nombre",
+		ColumnAddress:   "Dirección",
+		ColumnHours:     "Horario",
+		ColumnRating:    "Valoración",
+		ColumnPhone:     "Teléfono",
 		SpecialHours:    "horario especial",
 		NoSchedule:      "Horario no disponible",
+		HoursLookupFailed: "La consulta del horario falló: %v",
+		HoursNotChecked: "Horario no consultado para ahorrar créditos (ver --hours-for)",
+		HoursNotPublished: "El negocio no publica su horario",
+		WeekSchedule:    "Horario semanal:",
+		ClosedDay:       "cerrado",
+		OpenAllDay:      "abierto 24 horas",
+		AtTime:          "a las %s",
 		NotFound:        "No se encontraron resultados para \"%s\" en \"%s\"",
 		Found:           "Encontrados: %d resultados",
 		MoreResults:     "Hay %d resultados más. ¿Ver todos? [Y/N]: ",
@@ -69,6 +129,12 @@ var translations = map[Lang]Messages{
 		ErrorInvalidKey: "API Key inválida o expirada. Verifica tu key en https://serper.dev",
 		ErrorNoConnection: "No se pudo conectar. Verifica tu conexión a internet",
 		ErrorLimitReached: "Has alcanzado el límite de búsquedas. Más info en https://serper.dev",
+		ErrorTimeout:    "La búsqueda ha superado el tiempo máximo (--timeout)",
+		ErrorCancelled:  "Búsqueda cancelada",
+		ErrorRateLimited: "Demasiadas búsquedas seguidas. Espera unos segundos y vuelve a intentarlo",
+		ErrorServer:     "El servicio de búsqueda no responde. Vuelve a intentarlo más tarde",
+		ErrorInvalidResponse: "El servicio de búsqueda ha devuelto una respuesta no válida",
+		ErrorInvalidRequest: "No se pudo preparar la búsqueda. Revisa api-base-url y overpass-url",
 		ConfigSet:       "Configuración guardada: %s = %s",
 		ConfigGet:       "%s = %s",
 		CacheCleared:    "Caché limpiada correctamente",
@@ -76,6 +142,8 @@ var translations = map[Lang]Messages{
 		UninstallDone:   "pingbar ha sido desinstalado correctamente",
 		DeleteConfig:    "¿Deseas eliminar la configuración (~/.config/pingbar/)? [Y/N]: ",
 		DeleteCache:     "¿Deseas eliminar la caché? [Y/N]: ",
+		NotifyTitle:     "%s ha abierto",
+		NotifyMessage:   "Horario de hoy: %s",
 		Yes:             "Y",
 		No:              "N",
 	},
@@ -87,9 +155,35 @@ var translations = map[Lang]Messages{
 		Tomorrow:        "Tomorrow",
 		ClosesIn:        "closes in %s",
 		ClosedAgo:       "closed %s ago",
+		OpensIn:         "opens in %s",
 		Holiday:         "Today is a holiday, it may not be open",
+		HolidayAt:       "That day is a holiday, it may not be open",
+		AtHeader:        "Expected status on %s %s at %s",
+		LocalTime:       "local time %s, %s",
+		BatchStatus:     "STATUS",
+		BatchBusiness:   "BUSINESS",
+		BatchCity:       "CITY",
+		BatchAt:         "TIME",
+		BatchName:       "RESULT",
+		BatchHours:      "HOURS",
+		BatchNoResults:  "No results",
+		BatchError:      "ERROR",
+		BatchSummary:    "Open: %d · Closed: %d · Unknown: %d · Errors: %d",
+		ColumnStatus:    "Status",
+		ColumnName:      "Name",
+		ColumnAddress:   "Address",
+		ColumnHours:     "Hours",
+		ColumnRating:    "Rating",
+		ColumnPhone:     "Phone",
 		SpecialHours:    "special hours",
 		NoSchedule:      "Schedule not available",
+		HoursLookupFailed: "The schedule lookup failed: %v",
+		HoursNotChecked: "Schedule not looked up to save credits (see --hours-for)",
+		HoursNotPublished: "The business does not publish its schedule",
+		WeekSchedule:    "Weekly schedule:",
+		ClosedDay:       "closed",
+		OpenAllDay:      "open 24 hours",
+		AtTime:          "at %s",
 		NotFound:        "No results found for \"%s\" in \"%s\"",
 		Found:           "Found: %d results",
 		MoreResults:     "There are %d more results. View all? [Y/N]: ",
@@ -103,6 +197,12 @@ var translations = map[Lang]Messages{
 		ErrorInvalidKey: "Invalid or expired API Key. Check your key at https://serper.dev",
 		ErrorNoConnection: "Could not connect. Check your internet connection",
 		ErrorLimitReached: "You have reached the search limit. More info at https://serper.dev",
+		ErrorTimeout:    "The search exceeded the maximum time (--timeout)",
+		ErrorCancelled:  "Search cancelled",
+		ErrorRateLimited: "Too many searches in a row. Wait a few seconds and try again",
+		ErrorServer:     "The search service is not responding. Try again later",
+		ErrorInvalidResponse: "The search service returned an invalid response",
+		ErrorInvalidRequest: "Could not prepare the search. Check api-base-url and overpass-url",
 		ConfigSet:       "Configuration saved: %s = %s",
 		ConfigGet:       "%s = %s",
 		CacheCleared:    "Cache cleared successfully",
@@ -110,6 +210,8 @@ var translations = map[Lang]Messages{
 		UninstallDone:   "pingbar has been uninstalled successfully",
 		DeleteConfig:    "Do you want to delete configuration (~/.config/pingbar/)? [Y/N]: ",
 		DeleteCache:     "Do you want to delete cache? [Y/N]: ",
+		NotifyTitle:     "%s is now open",
+		NotifyMessage:   "Today's hours: %s",
 		Yes:             "Y",
 		No:              "N",
 	},
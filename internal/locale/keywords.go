@@ -0,0 +1,211 @@
+package locale
+
+import (
+	"strings"
+	"time"
+)
+
+// Keywords son las palabras de cada idioma que usan los extractores de horarios
+type Keywords struct {
+	Hours        []string                  // "horario", "hours"...: la primera se usa en la consulta
+	AllDay       []string                  // "24 horas", "24 hours"...
+	Days         map[string]time.Weekday   // Nombres de día sin ambigüedad
+	WeakDays     map[string]time.Weekday   // Abreviaturas que pueden ser otra palabra ("mar", "sun")
+	DaySets      map[string][]time.Weekday // "diario", "weekdays"...
+	Range        []string                  // Unen días u horas: "a", "to", "bis"
+	And          []string                  // Enumeran días u horas: "y", "and", "et"
+	Closed       []string                  // "cerrado", "closed", "fermé"...
+	HourSuffixes []string                  // Marcan que un número es una hora: "h", "uhr"
+	Fillers      []string                  // No cortan la secuencia día-hora: "de", "from", "le"
+}
+
+var (
+	everyDay = []time.Weekday{
+		time.Sunday, time.Monday, time.Tuesday, time.Wednesday,
+		time.Thursday, time.Friday, time.Saturday,
+	}
+	weekdays = []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}
+	weekend  = []time.Weekday{time.Saturday, time.Sunday}
+)
+
+var keywords = map[string]Keywords{
+	"es": {
+		Hours:  []string{"horario", "horarios"},
+		AllDay: []string{"24 horas", "24h"},
+		Days: map[string]time.Weekday{
+			"lunes": time.Monday, "martes": time.Tuesday,
+			"miércoles": time.Wednesday, "miercoles": time.Wednesday,
+			"jueves": time.Thursday, "viernes": time.Friday,
+			"sábado": time.Saturday, "sabado": time.Saturday,
+			"sábados": time.Saturday, "sabados": time.Saturday,
+			"domingo": time.Sunday, "domingos": time.Sunday,
+		},
+		WeakDays: map[string]time.Weekday{
+			"lun": time.Monday, "mar": time.Tuesday, "mié": time.Wednesday,
+			"mie": time.Wednesday, "jue": time.Thursday, "vie": time.Friday,
+			"sáb": time.Saturday, "sab": time.Saturday, "dom": time.Sunday,
+		},
+		DaySets: map[string][]time.Weekday{
+			"todos": everyDay, "diario": everyDay, "diariamente": everyDay,
+			"laborables": weekdays,
+		},
+		Range:        []string{"a", "al", "hasta"},
+		And:          []string{"y", "e"},
+		Closed:       []string{"cerrado", "cerrada", "cerramos", "descanso"},
+		HourSuffixes: []string{"h", "hs", "hrs", "horas"},
+		Fillers: []string{
+			"de", "del", "desde", "las", "los", "la", "el", "días", "dias",
+			"abierto", "abierta", "abre", "abrimos", "horario", "horarios",
+		},
+	},
+	"en": {
+		Hours:  []string{"opening hours", "hours", "open hours"},
+		AllDay: []string{"24 hours", "open 24/7", "24/7", "24h"},
+		Days: map[string]time.Weekday{
+			"monday": time.Monday, "mondays": time.Monday, "mon": time.Monday,
+			"tuesday": time.Tuesday, "tuesdays": time.Tuesday, "tue": time.Tuesday, "tues": time.Tuesday,
+			"wednesday": time.Wednesday, "wednesdays": time.Wednesday,
+			"thursday": time.Thursday, "thursdays": time.Thursday, "thu": time.Thursday, "thurs": time.Thursday,
+			"friday": time.Friday, "fridays": time.Friday, "fri": time.Friday,
+			"saturday": time.Saturday, "saturdays": time.Saturday, "sat": time.Saturday,
+			"sunday": time.Sunday, "sundays": time.Sunday,
+		},
+		WeakDays: map[string]time.Weekday{"wed": time.Wednesday, "sun": time.Sunday},
+		DaySets: map[string][]time.Weekday{
+			"daily": everyDay, "everyday": everyDay,
+			"weekdays": weekdays, "weekends": weekend,
+		},
+		Range:        []string{"to", "until", "till", "through", "thru"},
+		And:          []string{"and"},
+		Closed:       []string{"closed"},
+		HourSuffixes: []string{"h", "hrs", "hours"},
+		Fillers: []string{
+			"from", "on", "the", "at", "every", "day", "days", "open", "opens",
+			"opening", "hours",
+		},
+	},
+	"fr": {
+		Hours:  []string{"horaires", "horaire", "heures d'ouverture"},
+		AllDay: []string{"24h/24", "24 heures", "24h"},
+		Days: map[string]time.Weekday{
+			"lundi": time.Monday, "mardi": time.Tuesday, "mercredi": time.Wednesday,
+			"jeudi": time.Thursday, "vendredi": time.Friday, "samedi": time.Saturday,
+			"dimanche": time.Sunday,
+		},
+		WeakDays: map[string]time.Weekday{
+			"lun": time.Monday, "mar": time.Tuesday, "mer": time.Wednesday,
+			"jeu": time.Thursday, "ven": time.Friday, "sam": time.Saturday,
+			"dim": time.Sunday,
+		},
+		DaySets:      map[string][]time.Weekday{"tous": everyDay, "quotidien": everyDay},
+		Range:        []string{"à", "a", "au", "jusqu"},
+		And:          []string{"et"},
+		Closed:       []string{"fermé", "fermée", "ferme", "fermeture"},
+		HourSuffixes: []string{"h", "heures"},
+		Fillers: []string{
+			"de", "du", "des", "le", "les", "la", "jours", "jour", "ouvert",
+			"ouverte", "horaires", "horaire",
+		},
+	},
+	"pt": {
+		Hours:  []string{"horário", "horario", "horários"},
+		AllDay: []string{"24 horas", "24h"},
+		Days: map[string]time.Weekday{
+			"segunda": time.Monday, "terça": time.Tuesday, "terca": time.Tuesday,
+			"quarta": time.Wednesday, "quinta": time.Thursday, "sexta": time.Friday,
+			"sábado": time.Saturday, "sabado": time.Saturday,
+			"domingo": time.Sunday, "domingos": time.Sunday,
+		},
+		WeakDays: map[string]time.Weekday{
+			"seg": time.Monday, "ter": time.Tuesday, "qua": time.Wednesday,
+			"qui": time.Thursday, "sex": time.Friday, "sáb": time.Saturday,
+			"sab": time.Saturday, "dom": time.Sunday,
+		},
+		DaySets:      map[string][]time.Weekday{"todos": everyDay, "diariamente": everyDay},
+		Range:        []string{"a", "às", "as", "até", "ao"},
+		And:          []string{"e"},
+		Closed:       []string{"fechado", "fechada", "encerrado", "encerrada"},
+		HourSuffixes: []string{"h", "horas"},
+		Fillers: []string{
+			"de", "do", "da", "das", "dos", "os", "o", "feira", "dias",
+			"aberto", "aberta", "horário", "horario",
+		},
+	},
+	"it": {
+		Hours:  []string{"orari", "orario", "orari di apertura"},
+		AllDay: []string{"24 ore", "24 su 24", "24h"},
+		Days: map[string]time.Weekday{
+			"lunedì": time.Monday, "lunedi": time.Monday,
+			"martedì": time.Tuesday, "martedi": time.Tuesday,
+			"mercoledì": time.Wednesday, "mercoledi": time.Wednesday,
+			"giovedì": time.Thursday, "giovedi": time.Thursday,
+			"venerdì": time.Friday, "venerdi": time.Friday,
+			"sabato": time.Saturday, "domenica": time.Sunday,
+		},
+		WeakDays: map[string]time.Weekday{
+			"lun": time.Monday, "mar": time.Tuesday, "mer": time.Wednesday,
+			"gio": time.Thursday, "ven": time.Friday, "sab": time.Saturday,
+			"dom": time.Sunday,
+		},
+		DaySets:      map[string][]time.Weekday{"tutti": everyDay, "feriali": weekdays},
+		Range:        []string{"a", "al", "alle", "fino"},
+		And:          []string{"e", "ed"},
+		Closed:       []string{"chiuso", "chiusa", "chiusura"},
+		HourSuffixes: []string{"h", "ore"},
+		Fillers: []string{
+			"dal", "dalle", "il", "la", "le", "i", "giorni", "aperto", "aperta",
+			"orari", "orario", "ore",
+		},
+	},
+	"de": {
+		Hours:  []string{"öffnungszeiten", "geöffnet"},
+		AllDay: []string{"24 stunden", "rund um die uhr", "24h"},
+		Days: map[string]time.Weekday{
+			"montag": time.Monday, "dienstag": time.Tuesday, "mittwoch": time.Wednesday,
+			"donnerstag": time.Thursday, "freitag": time.Friday, "samstag": time.Saturday,
+			"sonnabend": time.Saturday, "sonntag": time.Sunday,
+		},
+		WeakDays: map[string]time.Weekday{
+			"mo": time.Monday, "di": time.Tuesday, "mi": time.Wednesday,
+			"do": time.Thursday, "fr": time.Friday, "sa": time.Saturday,
+			"so": time.Sunday,
+		},
+		DaySets:      map[string][]time.Weekday{"täglich": everyDay, "werktags": weekdays},
+		Range:        []string{"bis"},
+		And:          []string{"und"},
+		Closed:       []string{"geschlossen", "ruhetag"},
+		HourSuffixes: []string{"h", "uhr", "stunden"},
+		Fillers:      []string{"von", "am", "ab", "uhr", "geöffnet", "öffnungszeiten"},
+	},
+	"ca": {
+		Hours:  []string{"horari", "horaris"},
+		AllDay: []string{"24 hores", "24h"},
+		Days: map[string]time.Weekday{
+			"dilluns": time.Monday, "dimarts": time.Tuesday, "dimecres": time.Wednesday,
+			"dijous": time.Thursday, "divendres": time.Friday, "dissabte": time.Saturday,
+			"diumenge": time.Sunday,
+		},
+		DaySets:      map[string][]time.Weekday{"tots": everyDay, "diari": everyDay, "feiners": weekdays},
+		Range:        []string{"a", "al", "fins"},
+		And:          []string{"i"},
+		Closed:       []string{"tancat", "tancada"},
+		HourSuffixes: []string{"h", "hores"},
+		Fillers: []string{
+			"de", "del", "des", "les", "els", "la", "el", "dies", "obert",
+			"oberta", "horari", "horaris",
+		},
+	},
+}
+
+// KeywordsFor devuelve las palabras clave de un idioma (español si no se conoce)
+func KeywordsFor(lang string) Keywords {
+	if k, ok := keywords[strings.ToLower(lang)]; ok {
+		return k
+	}
+	return keywords["es"]
+}
+
+// Languages devuelve los idiomas de búsqueda con palabras clave conocidas
+func Languages() []string {
+	return []string{"es", "en", "fr", "pt", "it", "de", "ca"}
+}
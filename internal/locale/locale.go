@@ -0,0 +1,46 @@
+package locale
+
+import "strings"
+
+// DefaultCountry es el país de búsqueda por defecto
+const DefaultCountry = "es"
+
+// Country describe un país de búsqueda
+type Country struct {
+	Code     string // ISO 3166-1 alfa-2 en minúsculas
+	Name     string // Nombre usado en el parámetro location de la búsqueda
+	Language string // Idioma de búsqueda por defecto
+}
+
+var countries = map[string]Country{
+	"es": {Code: "es", Name: "España", Language: "es"},
+	"pt": {Code: "pt", Name: "Portugal", Language: "pt"},
+	"fr": {Code: "fr", Name: "France", Language: "fr"},
+	"it": {Code: "it", Name: "Italy", Language: "it"},
+	"de": {Code: "de", Name: "Germany", Language: "de"},
+	"at": {Code: "at", Name: "Austria", Language: "de"},
+	"ch": {Code: "ch", Name: "Switzerland", Language: "de"},
+	"be": {Code: "be", Name: "Belgium", Language: "fr"},
+	"nl": {Code: "nl", Name: "Netherlands", Language: "en"},
+	"ie": {Code: "ie", Name: "Ireland", Language: "en"},
+	"gb": {Code: "gb", Name: "United Kingdom", Language: "en"},
+	"us": {Code: "us", Name: "United States", Language: "en"},
+	"mx": {Code: "mx", Name: "Mexico", Language: "es"},
+	"ar": {Code: "ar", Name: "Argentina", Language: "es"},
+	"co": {Code: "co", Name: "Colombia", Language: "es"},
+	"cl": {Code: "cl", Name: "Chile", Language: "es"},
+	"br": {Code: "br", Name: "Brazil", Language: "pt"},
+}
+
+// LookupCountry devuelve el país con ese código. Los códigos desconocidos
+// se devuelven sin nombre y con el idioma por defecto.
+func LookupCountry(code string) Country {
+	code = strings.ToLower(strings.TrimSpace(code))
+	if code == "" {
+		code = DefaultCountry
+	}
+	if c, ok := countries[code]; ok {
+		return c
+	}
+	return Country{Code: code, Language: "en"}
+}
@@ -0,0 +1,152 @@
+package locale
+
+import (
+	"strings"
+
+	// Base de datos de zonas horarias incluida en el binario, para que
+	// funcione también en sistemas sin zoneinfo (Windows, contenedores)
+	_ "time/tzdata"
+)
+
+// countryZones es la zona horaria de la mayor parte de cada país
+var countryZones = map[string]string{
+	"es": "Europe/Madrid",
+	"pt": "Europe/Lisbon",
+	"fr": "Europe/Paris",
+	"it": "Europe/Rome",
+	"de": "Europe/Berlin",
+	"at": "Europe/Vienna",
+	"ch": "Europe/Zurich",
+	"be": "Europe/Brussels",
+	"nl": "Europe/Amsterdam",
+	"ie": "Europe/Dublin",
+	"gb": "Europe/London",
+	"us": "America/New_York",
+	"mx": "America/Mexico_City",
+	"ar": "America/Argentina/Buenos_Aires",
+	"co": "America/Bogota",
+	"cl": "America/Santiago",
+	"br": "America/Sao_Paulo",
+}
+
+// cityZones son las ciudades e islas con una zona distinta de la de su país,
+// y las de países con varias zonas
+var cityZones = map[string]map[string]string{
+	"es": {
+		"canarias":               "Atlantic/Canary",
+		"las palmas":             "Atlantic/Canary",
+		"gran canaria":           "Atlantic/Canary",
+		"santa cruz de tenerife": "Atlantic/Canary",
+		"tenerife":               "Atlantic/Canary",
+		"la laguna":              "Atlantic/Canary",
+		"lanzarote":              "Atlantic/Canary",
+		"arrecife":               "Atlantic/Canary",
+		"fuerteventura":          "Atlantic/Canary",
+		"puerto del rosario":     "Atlantic/Canary",
+		"la palma":               "Atlantic/Canary",
+		"santa cruz de la palma": "Atlantic/Canary",
+		"la gomera":              "Atlantic/Canary",
+		"el hierro":              "Atlantic/Canary",
+		"telde":                  "Atlantic/Canary",
+		"maspalomas":             "Atlantic/Canary",
+		"adeje":                  "Atlantic/Canary",
+		"arona":                  "Atlantic/Canary",
+		"puerto de la cruz":      "Atlantic/Canary",
+		"ceuta":                  "Africa/Ceuta",
+		"melilla":                "Africa/Ceuta",
+	},
+	"pt": {
+		"azores":            "Atlantic/Azores",
+		"açores":            "Atlantic/Azores",
+		"acores":            "Atlantic/Azores",
+		"ponta delgada":     "Atlantic/Azores",
+		"angra do heroismo": "Atlantic/Azores",
+		"madeira":           "Atlantic/Madeira",
+		"funchal":           "Atlantic/Madeira",
+	},
+	"us": {
+		"chicago":        "America/Chicago",
+		"houston":        "America/Chicago",
+		"dallas":         "America/Chicago",
+		"austin":         "America/Chicago",
+		"san antonio":    "America/Chicago",
+		"new orleans":    "America/Chicago",
+		"minneapolis":    "America/Chicago",
+		"denver":         "America/Denver",
+		"salt lake city": "America/Denver",
+		"phoenix":        "America/Phoenix",
+		"los angeles":    "America/Los_Angeles",
+		"san francisco":  "America/Los_Angeles",
+		"san diego":      "America/Los_Angeles",
+		"san jose":       "America/Los_Angeles",
+		"seattle":        "America/Los_Angeles",
+		"portland":       "America/Los_Angeles",
+		"las vegas":      "America/Los_Angeles",
+		"anchorage":      "America/Anchorage",
+		"honolulu":       "Pacific/Honolulu",
+	},
+	"mx": {
+		"cancun":     "America/Cancun",
+		"cancún":     "America/Cancun",
+		"tijuana":    "America/Tijuana",
+		"mexicali":   "America/Tijuana",
+		"hermosillo": "America/Hermosillo",
+		"chihuahua":  "America/Chihuahua",
+		"mazatlan":   "America/Mazatlan",
+		"mazatlán":   "America/Mazatlan",
+	},
+	"br": {
+		"manaus":    "America/Manaus",
+		"fortaleza": "America/Fortaleza",
+		"recife":    "America/Recife",
+		"salvador":  "America/Bahia",
+		"belem":     "America/Belem",
+		"belém":     "America/Belem",
+	},
+	"cl": {
+		"punta arenas":   "America/Punta_Arenas",
+		"isla de pascua": "Pacific/Easter",
+	},
+}
+
+// TimeZone devuelve la zona horaria IANA de un negocio a partir del país,
+// la ciudad buscada y su dirección. La ciudad buscada tiene prioridad; de la
+// dirección solo se mira la localidad, porque las calles con nombre de isla
+// o ciudad ("Calle de Tenerife", "Calle de la Palma") están en todas partes.
+func TimeZone(country, city, address string) string {
+	country = LookupCountry(country).Code
+	if zones, ok := cityZones[country]; ok {
+		if zone := matchZone(zones, city); zone != "" {
+			return zone
+		}
+		if zone := matchZone(zones, addressLocality(address)); zone != "" {
+			return zone
+		}
+	}
+	return countryZones[country]
+}
+
+// addressLocality quita la calle (el primer tramo separado por comas) de una
+// dirección como "Calle de Tenerife, 5, 28004 Madrid". Una dirección sin
+// comas se toma entera como localidad.
+func addressLocality(address string) string {
+	if i := strings.Index(address, ","); i >= 0 {
+		return address[i+1:]
+	}
+	return address
+}
+
+// matchZone busca en el texto la ciudad más larga de la tabla, como palabra completa
+func matchZone(zones map[string]string, text string) string {
+	text = " " + strings.Join(strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return r == ' ' || r == ',' || r == '.' || r == '(' || r == ')' || r == '-'
+	}), " ") + " "
+
+	zone, longest := "", 0
+	for name, z := range zones {
+		if len(name) > longest && strings.Contains(text, " "+name+" ") {
+			zone, longest = z, len(name)
+		}
+	}
+	return zone
+}
@@ -0,0 +1,44 @@
+package locale
+
+import "testing"
+
+func TestTimeZone(t *testing.T) {
+	tests := []struct {
+		country string
+		city    string
+		address string
+		want    string
+	}{
+		{"es", "madrid", "Calle Mayor, 1, 28013 Madrid", "Europe/Madrid"},
+		// Calles con nombre de isla o ciudad con otra zona
+		{"es", "madrid", "Calle de la Palma, 12, 28004 Madrid", "Europe/Madrid"},
+		{"es", "madrid", "Calle de Tenerife, 5, Madrid", "Europe/Madrid"},
+		{"es", "barcelona", "Carrer de Lanzarote, 3, 08020 Barcelona", "Europe/Madrid"},
+		{"es", "sevilla", "Calle Ceuta, 8, Sevilla", "Europe/Madrid"},
+		{"es", "valencia", "Av. de Melilla, 20, 46018 València", "Europe/Madrid"},
+		{"es", "santa cruz de tenerife", "Calle de Madrid, 4, 38001 Santa Cruz de Tenerife", "Atlantic/Canary"},
+		// La ciudad buscada tiene prioridad sobre la dirección
+		{"es", "las palmas", "Calle Triana, 1, Madrid", "Atlantic/Canary"},
+		// Sin zona en la ciudad buscada, se usa la localidad de la dirección
+		{"es", "canarias", "", "Atlantic/Canary"},
+		{"es", "islas", "Calle Real, 2, 35500 Arrecife", "Atlantic/Canary"},
+		{"es", "", "Paseo de las Palmeras, 9, Ceuta", "Africa/Ceuta"},
+		{"es", "", "Tenerife", "Atlantic/Canary"},
+		{"pt", "lisboa", "Rua da Madeira, 7, Lisboa", "Europe/Lisbon"},
+		{"pt", "funchal", "", "Atlantic/Madeira"},
+		{"us", "new york", "100 Broadway, New York, NY 10005", "America/New_York"},
+		{"us", "usa", "233 S Wacker Dr, Chicago, IL 60606", "America/Chicago"},
+		{"us", "phoenix", "", "America/Phoenix"},
+		// Código en mayúsculas o vacío (el país por defecto)
+		{"ES", "madrid", "", "Europe/Madrid"},
+		{"", "tenerife", "", "Atlantic/Canary"},
+		{"xx", "madrid", "", ""},
+	}
+	for _, tc := range tests {
+		t.Run(tc.country+"/"+tc.city+"/"+tc.address, func(t *testing.T) {
+			if got := TimeZone(tc.country, tc.city, tc.address); got != tc.want {
+				t.Errorf("TimeZone(%q, %q, %q) = %q, want %q", tc.country, tc.city, tc.address, got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package notify
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"runtime"
+)
+
+type command struct {
+	line string
+}
+
+// NewCommand crea un notificador que ejecuta un comando de la shell. El
+// comando recibe PINGBAR_NAME, PINGBAR_ADDRESS y PINGBAR_HOURS en el entorno.
+func NewCommand(line string) Notifier {
+	return &command{line: line}
+}
+
+func (c *command) Name() string {
+	return "command"
+}
+
+func (c *command) Notify(ctx context.Context, e Event) error {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", c.line)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", c.line)
+	}
+	cmd.Env = append(os.Environ(),
+		"PINGBAR_NAME="+e.Name,
+		"PINGBAR_ADDRESS="+e.Address,
+		"PINGBAR_HOURS="+e.Hours,
+	)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
@@ -0,0 +1,43 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+type dbus struct{}
+
+// NewDBus crea un notificador que muestra una notificación de escritorio con
+// org.freedesktop.Notifications. Llama al método Notify con gdbus.
+func NewDBus() Notifier {
+	return &dbus{}
+}
+
+// DBusAvailable indica si hay una sesión de D-Bus y gdbus para usarla
+func DBusAvailable() bool {
+	if os.Getenv("DBUS_SESSION_BUS_ADDRESS") == "" {
+		return false
+	}
+	_, err := exec.LookPath("gdbus")
+	return err == nil
+}
+
+func (d *dbus) Name() string {
+	return "dbus"
+}
+
+func (d *dbus) Notify(ctx context.Context, e Event) error {
+	cmd := exec.CommandContext(ctx, "gdbus", "call", "--session",
+		"--dest", "org.freedesktop.Notifications",
+		"--object-path", "/org/freedesktop/Notifications",
+		"--method", "org.freedesktop.Notifications.Notify",
+		"pingbar", "0", "", e.Title, e.Message, "[]", "{}", "5000",
+	)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("%v: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
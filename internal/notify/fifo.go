@@ -0,0 +1,39 @@
+package notify
+
+import (
+	"context"
+	"os"
+)
+
+type fifo struct {
+	path     string
+	jsonLang string
+}
+
+// NewFIFO crea un notificador que escribe el evento en JSON, una línea por
+// evento, en un FIFO con nombre (mkfifo) o en un archivo normal. Si nadie
+// está leyendo el FIFO, el aviso falla en lugar de bloquear la vigilancia.
+// Las claves van en el idioma jsonLang ("en" o "es").
+func NewFIFO(path, jsonLang string) Notifier {
+	return &fifo{path: path, jsonLang: jsonLang}
+}
+
+func (f *fifo) Name() string {
+	return "fifo"
+}
+
+func (f *fifo) Notify(ctx context.Context, e Event) error {
+	line, err := eventJSON(e, f.jsonLang)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(f.path, os.O_WRONLY|os.O_APPEND|openNonblock, 0)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = file.Write(append(line, '\n'))
+	return err
+}
@@ -0,0 +1,99 @@
+//go:build unix
+
+package notify
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+)
+
+func TestFIFORegularFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "avisos.jsonl")
+	if err := os.WriteFile(path, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	n := NewFIFO(path, "en")
+	for i := 0; i < 2; i++ {
+		if err := n.Notify(context.Background(), testEvent); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	// Una línea JSON por aviso, añadidas al final
+	lines := 0
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var event map[string]interface{}
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			t.Fatalf("línea %d no válida %q: %v", lines+1, scanner.Text(), err)
+		}
+		if event["event"] != "open" || event["name"] != "Bar Pronto" {
+			t.Errorf("línea %d = %s", lines+1, scanner.Text())
+		}
+		lines++
+	}
+	if lines != 2 {
+		t.Errorf("%d líneas, want 2", lines)
+	}
+}
+
+func TestFIFOWithReader(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pingbar.fifo")
+	if err := syscall.Mkfifo(path, 0o600); err != nil {
+		t.Skipf("mkfifo: %v", err)
+	}
+
+	// Abrir el lector sin bloquear para que el aviso encuentre al otro extremo
+	reader, err := os.OpenFile(path, os.O_RDONLY|syscall.O_NONBLOCK, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reader.Close()
+
+	if err := NewFIFO(path, "es").Notify(context.Background(), testEvent); err != nil {
+		t.Fatal(err)
+	}
+
+	line, err := bufio.NewReader(reader).ReadBytes('\n')
+	if err != nil {
+		t.Fatal(err)
+	}
+	var event map[string]interface{}
+	if err := json.Unmarshal(line, &event); err != nil {
+		t.Fatalf("línea no válida %q: %v", line, err)
+	}
+	if event["version_esquema"] != float64(1) || event["evento"] != "open" {
+		t.Errorf("aviso = %s", line)
+	}
+}
+
+func TestFIFOWithoutReader(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pingbar.fifo")
+	if err := syscall.Mkfifo(path, 0o600); err != nil {
+		t.Skipf("mkfifo: %v", err)
+	}
+
+	// Sin lector el aviso falla en lugar de bloquear
+	if err := NewFIFO(path, "en").Notify(context.Background(), testEvent); err == nil {
+		t.Error("err = nil sin lector en el FIFO")
+	}
+}
+
+func TestFIFOMissing(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "no-existe")
+	if err := NewFIFO(path, "en").Notify(context.Background(), testEvent); err == nil {
+		t.Error("err = nil con un FIFO que no existe")
+	}
+}
@@ -0,0 +1,8 @@
+//go:build unix
+
+package notify
+
+import "syscall"
+
+// Abrir un FIFO sin lector falla con ENXIO en lugar de bloquear
+const openNonblock = syscall.O_NONBLOCK
@@ -0,0 +1,80 @@
+// Package notify envía avisos cuando un negocio vigilado abre: ejecutando un
+// comando, escribiendo en un FIFO, con un webhook o con una notificación de
+// escritorio por D-Bus.
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Event describe la apertura de un negocio
+type Event struct {
+	Title   string // Título del aviso, ya traducido
+	Message string // Texto del aviso, ya traducido
+	Name    string
+	Address string
+	Hours   string // Horario del día
+	At      time.Time
+}
+
+// Notifier envía un aviso por un canal concreto
+type Notifier interface {
+	Name() string
+	Notify(ctx context.Context, e Event) error
+}
+
+// Config indica qué notificadores usar. Los campos vacíos no se usan.
+type Config struct {
+	Command string // Comando de la shell
+	FIFO    string // Ruta de un FIFO (o de un archivo) donde escribir
+	Webhook string // URL a la que enviar un POST con el evento en JSON
+	DBus    string // "on", "off" o "auto" (solo si hay sesión de D-Bus)
+
+	JSONLang string // Idioma de las claves del JSON del FIFO y del webhook
+}
+
+// FromConfig crea los notificadores configurados
+func FromConfig(cfg Config) ([]Notifier, error) {
+	var notifiers []Notifier
+	if cfg.Command != "" {
+		notifiers = append(notifiers, NewCommand(cfg.Command))
+	}
+	if cfg.FIFO != "" {
+		notifiers = append(notifiers, NewFIFO(cfg.FIFO, cfg.JSONLang))
+	}
+	if cfg.Webhook != "" {
+		notifiers = append(notifiers, NewWebhook(cfg.Webhook, cfg.JSONLang))
+	}
+
+	switch cfg.DBus {
+	case "on":
+		if !DBusAvailable() {
+			return nil, fmt.Errorf("D-Bus no disponible: hace falta gdbus y una sesión de escritorio")
+		}
+		notifiers = append(notifiers, NewDBus())
+	case "", "auto":
+		if DBusAvailable() {
+			notifiers = append(notifiers, NewDBus())
+		}
+	}
+
+	if len(notifiers) == 0 {
+		return nil, errors.New("no hay notificadores configurados (usa pingbar config set notify-command, notify-fifo o notify-webhook)")
+	}
+	return notifiers, nil
+}
+
+// Send envía el evento a todos los notificadores. Un fallo no impide el
+// envío a los demás; los errores se devuelven juntos.
+func Send(ctx context.Context, notifiers []Notifier, e Event) error {
+	var errs []error
+	for _, n := range notifiers {
+		if err := n.Notify(ctx, e); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", n.Name(), err))
+		}
+	}
+	return errors.Join(errs...)
+}
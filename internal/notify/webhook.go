@@ -0,0 +1,67 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/686f6c61/pingbar/internal/output"
+)
+
+type webhook struct {
+	url      string
+	jsonLang string
+	client   *http.Client
+}
+
+// NewWebhook crea un notificador que envía el evento en JSON con un POST,
+// con las claves en el idioma jsonLang ("en" o "es")
+func NewWebhook(url, jsonLang string) Notifier {
+	return &webhook{url: url, jsonLang: jsonLang, client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+func (w *webhook) Name() string {
+	return "webhook"
+}
+
+func (w *webhook) Notify(ctx context.Context, e Event) error {
+	body, err := eventJSON(e, w.jsonLang)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", w.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "pingbar")
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("respuesta %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// eventJSON codifica el evento como lo reciben el webhook y el FIFO
+// (pingbar schema notify)
+func eventJSON(e Event, lang string) ([]byte, error) {
+	return output.MarshalJSON(output.EventJSON{
+		SchemaVersion: output.SchemaVersion,
+		Event:         "open",
+		Title:         e.Title,
+		Message:       e.Message,
+		Name:          e.Name,
+		Address:       e.Address,
+		Hours:         e.Hours,
+		Time:          e.At.Format(time.RFC3339),
+	}, lang)
+}
@@ -0,0 +1,120 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+var testEvent = Event{
+	Title:   "Bar Pronto ha abierto",
+	Message: "Horario de hoy: 17:53 - 23:59",
+	Name:    "Bar Pronto",
+	Address: "Calle 1, Madrid",
+	Hours:   "17:53 - 23:59",
+	At:      time.Date(2026, 10, 17, 17, 53, 17, 0, time.UTC),
+}
+
+// request es una petición recibida por el servidor de prueba
+type request struct {
+	method string
+	header http.Header
+	body   []byte
+}
+
+// newWebhookServer arranca un servidor que responde status y envía cada
+// petición recibida a requests
+func newWebhookServer(t *testing.T, status int) (*httptest.Server, <-chan request) {
+	t.Helper()
+	requests := make(chan request, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests <- request{method: r.Method, header: r.Header, body: body}
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+	return server, requests
+}
+
+func TestWebhookPayload(t *testing.T) {
+	server, requests := newWebhookServer(t, http.StatusNoContent)
+
+	if err := NewWebhook(server.URL, "en").Notify(context.Background(), testEvent); err != nil {
+		t.Fatal(err)
+	}
+
+	r := <-requests
+	if r.method != "POST" {
+		t.Errorf("method = %s, want POST", r.method)
+	}
+	if ct := r.header.Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Content-Type = %q", ct)
+	}
+	if ua := r.header.Get("User-Agent"); ua != "pingbar" {
+		t.Errorf("User-Agent = %q", ua)
+	}
+
+	var got map[string]interface{}
+	if err := json.Unmarshal(r.body, &got); err != nil {
+		t.Fatalf("cuerpo no válido %q: %v", r.body, err)
+	}
+	want := map[string]interface{}{
+		"schema_version": float64(1),
+		"event":          "open",
+		"title":          "Bar Pronto ha abierto",
+		"message":        "Horario de hoy: 17:53 - 23:59",
+		"name":           "Bar Pronto",
+		"address":        "Calle 1, Madrid",
+		"hours":          "17:53 - 23:59",
+		"time":           "2026-10-17T17:53:17Z",
+	}
+	if len(got) != len(want) {
+		t.Errorf("claves = %v, want %d", got, len(want))
+	}
+	for key, value := range want {
+		if got[key] != value {
+			t.Errorf("%s = %v, want %v", key, got[key], value)
+		}
+	}
+}
+
+func TestWebhookSpanishKeys(t *testing.T) {
+	server, requests := newWebhookServer(t, http.StatusOK)
+
+	if err := NewWebhook(server.URL, "es").Notify(context.Background(), testEvent); err != nil {
+		t.Fatal(err)
+	}
+
+	body := string((<-requests).body)
+	for _, key := range []string{`"version_esquema":1`, `"evento":"open"`, `"titulo":`, `"mensaje":`, `"nombre":`, `"direccion":`, `"horario":`, `"hora":`} {
+		if !strings.Contains(body, key) {
+			t.Errorf("el cuerpo no contiene %s: %s", key, body)
+		}
+	}
+}
+
+func TestWebhookErrorStatus(t *testing.T) {
+	for _, status := range []int{http.StatusBadRequest, http.StatusInternalServerError} {
+		server, _ := newWebhookServer(t, status)
+		err := NewWebhook(server.URL, "en").Notify(context.Background(), testEvent)
+		if want := fmt.Sprintf("respuesta %d", status); err == nil || err.Error() != want {
+			t.Errorf("err = %v, want %s", err, want)
+		}
+	}
+}
+
+func TestWebhookUnreachable(t *testing.T) {
+	server, _ := newWebhookServer(t, http.StatusOK)
+	url := server.URL
+	server.Close()
+
+	if err := NewWebhook(url, "en").Notify(context.Background(), testEvent); err == nil {
+		t.Error("err = nil con el servidor cerrado")
+	}
+}
@@ -0,0 +1,193 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/686f6c61/pingbar/internal/api"
+	"github.com/686f6c61/pingbar/internal/i18n"
+	"github.com/fatih/color"
+)
+
+// BatchResult es el resultado de una línea de pingbar batch
+type BatchResult struct {
+	Business string
+	City     string
+	At       time.Time         // Instante de la línea; sin valor, el actual
+	Result   *api.BusinessInfo // Primer resultado; nil si no hay o si la búsqueda falló
+	Err      error
+}
+
+// BatchSummary cuenta los negocios de un lote según su estado
+type BatchSummary struct {
+	Open    int
+	Closed  int
+	Unknown int // Sin horario o sin resultados
+	Errors  int
+}
+
+// Add cuenta un resultado
+func (s *BatchSummary) Add(r BatchResult) {
+	switch {
+	case r.Err != nil:
+		s.Errors++
+	case r.Result == nil || r.Result.IsUnknown:
+		s.Unknown++
+	case r.Result.IsOpen:
+		s.Open++
+	default:
+		s.Closed++
+	}
+}
+
+// PrintBatchLine imprime un resultado del lote como una línea JSON (NDJSON)
+func (f *Formatter) PrintBatchLine(r BatchResult) {
+	line := BatchLineJSON{
+		SchemaVersion: SchemaVersion,
+		Business:      r.Business,
+		City:          r.City,
+	}
+	if !r.At.IsZero() {
+		line.At = r.At.Format(time.RFC3339)
+	}
+	if r.Result != nil {
+		result := f.resultJSON(*r.Result, false)
+		line.Result = &result
+	}
+	if r.Err != nil {
+		line.Error = errorMessage(r.Err, i18n.Get(f.Lang))
+	}
+
+	f.writeJSON(line, false)
+}
+
+// PrintBatchTable imprime los resultados del lote como una tabla, una fila
+// por línea de la entrada
+func (f *Formatter) PrintBatchTable(results []BatchResult) {
+	msgs := i18n.Get(f.Lang)
+	bold := color.New(color.Bold)
+	gray := color.New(color.FgHiBlack)
+
+	withAt := false
+	for _, r := range results {
+		if !r.At.IsZero() {
+			withAt = true
+		}
+	}
+
+	header := []string{msgs.BatchStatus, msgs.BatchBusiness, msgs.BatchCity}
+	if withAt {
+		header = append(header, msgs.BatchAt)
+	}
+	header = append(header, msgs.BatchName, msgs.BatchHours)
+
+	rows := make([][]string, 0, len(results))
+	for _, r := range results {
+		row := []string{f.batchStatus(r), r.Business, r.City}
+		if withAt {
+			at := ""
+			if !r.At.IsZero() {
+				at = fmt.Sprintf("%s %s", i18n.GetDay(f.Lang, int(r.At.Weekday())), r.At.Format("02/01 15:04"))
+			}
+			row = append(row, at)
+		}
+		switch {
+		case r.Err != nil:
+			row = append(row, "", errorMessage(r.Err, msgs))
+		case r.Result == nil:
+			row = append(row, "", msgs.BatchNoResults)
+		default:
+			hours := DayHours(msgs, r.Result.Today, r.Result.TodayHours)
+			if hours == "" {
+				hours = msgs.NoSchedule
+			}
+			row = append(row, r.Result.Name, hours)
+		}
+		rows = append(rows, row)
+	}
+
+	// Ancho de cada columna salvo la última, que no se rellena
+	widths := make([]int, len(header)-1)
+	for _, row := range append([][]string{header}, rows...) {
+		for i := range widths {
+			if n := utf8.RuneCountInString(row[i]); n > widths[i] {
+				widths[i] = n
+			}
+		}
+	}
+
+	bold.Println(batchRow(header, widths))
+	for i, row := range rows {
+		r := results[i]
+		statusColor := f.batchStatusColor(r)
+		statusColor.Print(padRight(row[0], widths[0]))
+		line := "  " + batchRow(row[1:], widths[1:])
+		if r.Err != nil || r.Result == nil {
+			gray.Println(line)
+		} else {
+			fmt.Println(line)
+		}
+	}
+}
+
+// batchRow une las celdas de una fila rellenando cada una hasta su ancho
+func batchRow(cells []string, widths []int) string {
+	padded := make([]string, len(cells))
+	for i, cell := range cells {
+		if i < len(widths) {
+			cell = padRight(cell, widths[i])
+		}
+		padded[i] = cell
+	}
+	return strings.TrimRight(strings.Join(padded, "  "), " ")
+}
+
+// batchStatus devuelve la etiqueta de estado de un resultado del lote
+func (f *Formatter) batchStatus(r BatchResult) string {
+	msgs := i18n.Get(f.Lang)
+	switch {
+	case r.Err != nil:
+		return "[" + msgs.BatchError + "]"
+	case r.Result == nil || r.Result.IsUnknown:
+		return "[" + msgs.Unknown + "]"
+	case r.Result.IsOpen:
+		return "[" + msgs.Open + "]"
+	}
+	return "[" + msgs.Closed + "]"
+}
+
+// batchStatusColor devuelve el color de la etiqueta de estado
+func (f *Formatter) batchStatusColor(r BatchResult) *color.Color {
+	switch {
+	case r.Err != nil:
+		return color.New(color.FgRed)
+	case r.Result == nil || r.Result.IsUnknown:
+		return color.New(color.FgYellow, color.Bold)
+	case r.Result.IsOpen:
+		return color.New(color.FgGreen, color.Bold)
+	}
+	return color.New(color.FgRed, color.Bold)
+}
+
+// PrintBatchSummary imprime el recuento del lote. En JSON, como una última
+// línea {"summary": {...}}.
+func (f *Formatter) PrintBatchSummary(s BatchSummary) {
+	if f.JSONLines() {
+		f.writeJSON(BatchSummaryJSON{
+			SchemaVersion: SchemaVersion,
+			Summary: BatchSummaryKeys{
+				Open:    s.Open,
+				Closed:  s.Closed,
+				Unknown: s.Unknown,
+				Errors:  s.Errors,
+			},
+		}, false)
+		return
+	}
+
+	msgs := i18n.Get(f.Lang)
+	fmt.Println()
+	fmt.Printf(msgs.BatchSummary+"\n", s.Open, s.Closed, s.Unknown, s.Errors)
+}
@@ -0,0 +1,309 @@
+package output
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/686f6c61/pingbar/internal/api"
+	"github.com/686f6c61/pingbar/internal/i18n"
+)
+
+// DefaultFormat es el formato de salida si no se indica otro
+const DefaultFormat = "text"
+
+// FormatFunc imprime los resultados de una búsqueda en un formato de salida
+type FormatFunc func(f *Formatter, results []api.BusinessInfo, business, city string, showWeek bool)
+
+var formats = map[string]FormatFunc{
+	"text":     (*Formatter).printText,
+	"json":     (*Formatter).printJSON,
+	"ndjson":   (*Formatter).printNDJSON,
+	"csv":      (*Formatter).printCSV,
+	"tsv":      (*Formatter).printTSV,
+	"yaml":     (*Formatter).printYAML,
+	"markdown": (*Formatter).printMarkdown,
+	"template": (*Formatter).printTemplate,
+}
+
+// RegisterFormat añade un formato de salida al registro
+func RegisterFormat(name string, format FormatFunc) {
+	formats[name] = format
+}
+
+// Formats devuelve los nombres de los formatos registrados
+func Formats() []string {
+	names := make([]string, 0, len(formats))
+	for name := range formats {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// JSONLines indica si el formato imprime una línea JSON por elemento, como
+// necesitan watch y batch
+func (f *Formatter) JSONLines() bool {
+	return f.Format == "json" || f.Format == "ndjson"
+}
+
+// ResultLineJSON es una línea de la salida ndjson: un resultado con la
+// versión del esquema
+type ResultLineJSON struct {
+	SchemaVersion int `json:"schema_version"`
+	ResultJSON
+}
+
+// printNDJSON imprime un resultado por línea
+func (f *Formatter) printNDJSON(results []api.BusinessInfo, business, city string, showWeek bool) {
+	for _, r := range results {
+		f.writeJSON(ResultLineJSON{SchemaVersion: SchemaVersion, ResultJSON: f.resultJSON(r, showWeek)}, false)
+	}
+}
+
+// tableColumns son las columnas de csv y tsv, con las claves de la salida JSON
+var tableColumns = []string{
+	"name", "address", "status", "open", "hours", "closes_in_minutes", "opens_in_minutes",
+	"timezone", "local_time", "holiday", "holiday_name", "rating", "reviews",
+	"category", "phone", "website", "hours_error",
+}
+
+// tableRow devuelve las celdas de un resultado en el orden de tableColumns
+func tableRow(r ResultJSON) []string {
+	minutes := func(n int) string {
+		if n == 0 {
+			return ""
+		}
+		return strconv.Itoa(n)
+	}
+	return []string{
+		r.Name, r.Address, string(r.Status), strconv.FormatBool(r.Open), r.Hours,
+		minutes(r.ClosesInMinutes), minutes(r.OpensInMinutes),
+		r.TimeZone, r.LocalTime, strconv.FormatBool(r.Holiday), r.HolidayName,
+		strconv.FormatFloat(r.Rating, 'f', -1, 64), strconv.Itoa(r.Reviews),
+		r.Category, r.Phone, r.Website, r.HoursError,
+	}
+}
+
+// printCSV imprime los resultados en CSV con una fila de cabecera
+func (f *Formatter) printCSV(results []api.BusinessInfo, business, city string, showWeek bool) {
+	f.printDelimited(results, ',')
+}
+
+// printTSV imprime los resultados separados por tabuladores con una fila de cabecera
+func (f *Formatter) printTSV(results []api.BusinessInfo, business, city string, showWeek bool) {
+	f.printDelimited(results, '\t')
+}
+
+func (f *Formatter) printDelimited(results []api.BusinessInfo, comma rune) {
+	f.writeDelimited(os.Stdout, results, comma)
+}
+
+// writeDelimited escribe la cabecera, con las claves en el idioma de la
+// salida JSON, y una fila por resultado
+func (f *Formatter) writeDelimited(out io.Writer, results []api.BusinessInfo, comma rune) {
+	w := csv.NewWriter(out)
+	w.Comma = comma
+
+	header := make([]string, len(tableColumns))
+	for i, key := range tableColumns {
+		header[i] = jsonKey(f.JSONLang, "results", key)
+	}
+	w.Write(header)
+	for _, r := range results {
+		w.Write(tableRow(f.resultJSON(r, false)))
+	}
+	w.Flush()
+}
+
+// printMarkdown imprime los resultados como una tabla de Markdown, para
+// pegarla en una wiki
+func (f *Formatter) printMarkdown(results []api.BusinessInfo, business, city string, showWeek bool) {
+	msgs := i18n.Get(f.Lang)
+
+	cell := strings.NewReplacer("|", `\|`, "\n", " ", "\r", "")
+	row := func(cells ...string) {
+		for i := range cells {
+			cells[i] = cell.Replace(cells[i])
+		}
+		fmt.Printf("| %s |\n", strings.Join(cells, " | "))
+	}
+
+	row(msgs.ColumnStatus, msgs.ColumnName, msgs.ColumnAddress, msgs.ColumnHours, msgs.ColumnRating, msgs.ColumnPhone)
+	fmt.Println("|---|---|---|---|---|---|")
+	for _, r := range results {
+		status := msgs.Closed
+		switch {
+		case r.IsUnknown:
+			status = msgs.Unknown
+		case r.IsOpen:
+			status = msgs.Open
+		}
+		if r.Holiday != "" && !r.IsUnknown {
+			status += "?"
+		}
+
+		hours := DayHours(msgs, r.Today, r.TodayHours)
+		if hours == "" {
+			hours = msgs.NoSchedule
+		}
+
+		rating := ""
+		if r.Rating > 0 {
+			rating = fmt.Sprintf("%.1f (%d)", r.Rating, r.RatingCount)
+		}
+
+		row(strings.TrimSpace(status), r.Name, r.Address, hours, rating, r.Phone)
+	}
+}
+
+// printYAML imprime el mismo documento que printJSON en YAML
+func (f *Formatter) printYAML(results []api.BusinessInfo, business, city string, showWeek bool) {
+	data, err := f.marshalJSON(f.searchJSON(results, business, city, showWeek))
+	if err == nil {
+		data, err = jsonToYAML(data)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error al generar YAML: %v\n", err)
+		return
+	}
+	os.Stdout.Write(data)
+}
+
+// yamlNode es un valor JSON que conserva el orden de las claves
+type yamlNode struct {
+	keys   []string // Claves, si es un objeto
+	items  []yamlNode
+	scalar interface{}
+	object bool
+	array  bool
+}
+
+// jsonToYAML convierte un documento JSON en YAML conservando el orden de
+// las claves. Las cadenas se escriben siempre entre comillas dobles, que en
+// YAML admiten los mismos escapes que JSON.
+func jsonToYAML(data []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	root, err := readYAMLNode(dec)
+	if err != nil {
+		return nil, err
+	}
+
+	var out bytes.Buffer
+	switch {
+	case root.object && len(root.items) == 0:
+		out.WriteString("{}\n")
+	case root.array && len(root.items) == 0:
+		out.WriteString("[]\n")
+	case root.object || root.array:
+		writeYAMLNode(&out, root, "")
+	default:
+		fmt.Fprintln(&out, yamlScalar(root.scalar))
+	}
+	return out.Bytes(), nil
+}
+
+func readYAMLNode(dec *json.Decoder) (yamlNode, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return yamlNode{}, err
+	}
+
+	d, ok := tok.(json.Delim)
+	if !ok {
+		return yamlNode{scalar: tok}, nil
+	}
+
+	node := yamlNode{object: d == '{', array: d == '['}
+	for dec.More() {
+		if node.object {
+			key, err := dec.Token()
+			if err != nil {
+				return yamlNode{}, err
+			}
+			node.keys = append(node.keys, key.(string))
+		}
+		item, err := readYAMLNode(dec)
+		if err != nil {
+			return yamlNode{}, err
+		}
+		node.items = append(node.items, item)
+	}
+	// Cierre del objeto o del array
+	if _, err := dec.Token(); err != nil && err != io.EOF {
+		return yamlNode{}, err
+	}
+	return node, nil
+}
+
+var yamlPlainKey = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// yamlReservedKey indica si una clave sin comillas se leería como booleano o
+// null en YAML 1.1 o 1.2
+func yamlReservedKey(key string) bool {
+	switch strings.ToLower(key) {
+	case "null", "true", "false", "yes", "no", "on", "off", "y", "n":
+		return true
+	}
+	return false
+}
+
+// writeYAMLNode escribe un objeto o un array con la sangría indent
+func writeYAMLNode(out *bytes.Buffer, node yamlNode, indent string) {
+	for i, item := range node.items {
+		prefix := indent + "- "
+		if node.object {
+			key := node.keys[i]
+			if !yamlPlainKey.MatchString(key) || yamlReservedKey(key) {
+				key = yamlScalar(key)
+			}
+			prefix = indent + key + ":"
+		}
+
+		switch {
+		case (item.object || item.array) && len(item.items) == 0:
+			if item.object {
+				fmt.Fprintf(out, "%s {}\n", strings.TrimRight(prefix, " "))
+			} else {
+				fmt.Fprintf(out, "%s []\n", strings.TrimRight(prefix, " "))
+			}
+		case item.object && node.array:
+			// La primera clave va en la misma línea que el guion
+			var nested bytes.Buffer
+			writeYAMLNode(&nested, item, indent+"  ")
+			out.WriteString(prefix)
+			out.Write(nested.Bytes()[len(indent)+2:])
+		case item.object || item.array:
+			fmt.Fprintln(out, strings.TrimRight(prefix, " "))
+			writeYAMLNode(out, item, indent+"  ")
+		case node.object:
+			fmt.Fprintf(out, "%s %s\n", prefix, yamlScalar(item.scalar))
+		default:
+			fmt.Fprintf(out, "%s%s\n", prefix, yamlScalar(item.scalar))
+		}
+	}
+}
+
+// yamlScalar escribe un valor simple de JSON en YAML
+func yamlScalar(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return "null"
+	case string:
+		// Sin escapar <, > y &, que en YAML no hace falta
+		var data bytes.Buffer
+		enc := json.NewEncoder(&data)
+		enc.SetEscapeHTML(false)
+		enc.Encode(v)
+		return strings.TrimSuffix(data.String(), "\n")
+	}
+	return fmt.Sprint(v)
+}
@@ -0,0 +1,169 @@
+package output
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strings"
+	"testing"
+
+	"github.com/686f6c61/pingbar/internal/api"
+)
+
+func TestJSONToYAML(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{
+			name: "arrays de objetos anidados",
+			in:   `{"results":[{"name":"A","week":[{"day":"lunes","intervals":[{"opens":"10:00","closes":"14:00"}]}]},{"name":"B"}]}`,
+			want: `results:
+  - name: "A"
+    week:
+      - day: "lunes"
+        intervals:
+          - opens: "10:00"
+            closes: "14:00"
+  - name: "B"
+`,
+		},
+		{
+			name: "arrays dentro de arrays",
+			in:   `{"a":[[1,2],[{"x":1}]]}`,
+			want: `a:
+  -
+    - 1
+    - 2
+  -
+    - x: 1
+`,
+		},
+		{
+			name: "objetos y arrays vacíos",
+			in:   `{"a":{},"b":[],"c":[{},[]]}`,
+			want: `a: {}
+b: []
+c:
+  - {}
+  - []
+`,
+		},
+		{
+			name: "null, booleanos y números",
+			in:   `{"tomorrow":null,"open":true,"rating":4.5,"reviews":1200}`,
+			want: `tomorrow: null
+open: true
+rating: 4.5
+reviews: 1200
+`,
+		},
+		{
+			name: "claves que necesitan comillas",
+			in:   `{"24h":true,"a b":1,"":2,"yes":3,"null":4,"On":5}`,
+			want: `"24h": true
+"a b": 1
+"": 2
+"yes": 3
+"null": 4
+"On": 5
+`,
+		},
+		{
+			name: "cadenas con dos puntos, almohadilla y no ASCII",
+			in:   `{"hours":"10:00 - 14:00","name":"Bar #1: el \"mejor\"","address":"Plaça d'Espanya, Málaga ñ","website":"https://x.es/?a=1&b=<2>","ñ":"\n"}`,
+			want: `hours: "10:00 - 14:00"
+name: "Bar #1: el \"mejor\""
+address: "Plaça d'Espanya, Málaga ñ"
+website: "https://x.es/?a=1&b=<2>"
+"ñ": "\n"
+`,
+		},
+		{
+			name: "documento vacío",
+			in:   `{}`,
+			want: "{}\n",
+		},
+		{
+			name: "array vacío",
+			in:   `[]`,
+			want: "[]\n",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := jsonToYAML([]byte(tc.in))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tc.want {
+				t.Errorf("jsonToYAML(%s)\n got:\n%s\nwant:\n%s", tc.in, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestJSONToYAMLSpanishKeys(t *testing.T) {
+	info := api.BusinessInfo{Name: "Bar Pepe", IsOpen: true}
+	f, err := NewFormatter("es", "off", "yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.JSONLang = "es"
+
+	data, err := f.marshalJSON(f.searchJSON([]api.BusinessInfo{info}, "bar", "madrid", true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := jsonToYAML(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"version_esquema: ", "resultados:\n  - nombre: \"Bar Pepe\"\n", "    abierto: true\n"} {
+		if !strings.Contains(string(got), want) {
+			t.Errorf("falta %q en:\n%s", want, got)
+		}
+	}
+}
+
+func TestDelimitedHeader(t *testing.T) {
+	tests := []struct {
+		jsonLang string
+		comma    rune
+		want     []string
+	}{
+		{"en", ',', tableColumns},
+		{"en", '\t', tableColumns},
+		{"es", ',', []string{
+			"nombre", "direccion", "estado", "abierto", "horario", "cierra_en_minutos", "abre_en_minutos",
+			"zona_horaria", "hora_local", "festivo", "festivo_nombre", "rating", "opiniones",
+			"categoria", "telefono", "website", "error_horario",
+		}},
+	}
+	for _, tc := range tests {
+		f, err := NewFormatter("es", "off", "csv")
+		if err != nil {
+			t.Fatal(err)
+		}
+		f.JSONLang = tc.jsonLang
+
+		var out bytes.Buffer
+		f.writeDelimited(&out, []api.BusinessInfo{{Name: "Bar, Pepe", Address: "Calle \"Mayor\" 1"}}, tc.comma)
+
+		r := csv.NewReader(&out)
+		r.Comma = tc.comma
+		rows, err := r.ReadAll()
+		if err != nil {
+			t.Fatalf("--json-lang %s %q: %v", tc.jsonLang, tc.comma, err)
+		}
+		if len(rows) != 2 {
+			t.Fatalf("--json-lang %s %q: %d filas, want 2", tc.jsonLang, tc.comma, len(rows))
+		}
+		if strings.Join(rows[0], "|") != strings.Join(tc.want, "|") {
+			t.Errorf("--json-lang %s %q: cabecera %v, want %v", tc.jsonLang, tc.comma, rows[0], tc.want)
+		}
+		if rows[1][0] != "Bar, Pepe" || rows[1][1] != "Calle \"Mayor\" 1" {
+			t.Errorf("--json-lang %s %q: fila %v", tc.jsonLang, tc.comma, rows[1])
+		}
+	}
+}
@@ -0,0 +1,324 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+)
+
+// SchemaVersion es la versión del formato de la salida JSON. Cambia solo si
+// se quita o cambia de significado algún campo; añadir campos no la cambia.
+const SchemaVersion = 1
+
+// Status es el estado de un negocio en la salida JSON
+type Status string
+
+const (
+	StatusOpen    Status = "open"
+	StatusClosed  Status = "closed"
+	StatusUnknown Status = "unknown"
+)
+
+// statusOf devuelve el estado de un negocio
+func statusOf(isOpen, isUnknown bool) Status {
+	switch {
+	case isUnknown:
+		return StatusUnknown
+	case isOpen:
+		return StatusOpen
+	}
+	return StatusClosed
+}
+
+// SearchJSON es la salida JSON de una búsqueda
+type SearchJSON struct {
+	SchemaVersion int          `json:"schema_version"`
+	Query         QueryJSON    `json:"query"`
+	Total         int          `json:"total"`
+	Results       []ResultJSON `json:"results"`
+}
+
+// QueryJSON es la consulta de una búsqueda
+type QueryJSON struct {
+	Business string `json:"business"`
+	City     string `json:"city"`
+	At       string `json:"at,omitempty"` // Instante de --at (RFC 3339)
+}
+
+// ResultJSON es un negocio encontrado
+type ResultJSON struct {
+	Name            string        `json:"name"`
+	Address         string        `json:"address"`
+	Status          Status        `json:"status"`
+	Open            bool          `json:"open"`
+	Hours           string        `json:"hours,omitempty"`
+	HoursError      string        `json:"hours_error,omitempty"`
+	ClosesInMinutes int           `json:"closes_in_minutes,omitempty"`
+	OpensInMinutes  int           `json:"opens_in_minutes,omitempty"`
+	TimeZone        string        `json:"timezone,omitempty"`
+	LocalTime       string        `json:"local_time,omitempty"` // Hora local del negocio usada (RFC 3339)
+	Holiday         bool          `json:"holiday"`
+	HolidayName     string        `json:"holiday_name,omitempty"`
+	Uncertain       bool          `json:"uncertain,omitempty"` // En festivo el horario habitual puede no aplicarse
+	Rating          float64       `json:"rating"`
+	Reviews         int           `json:"reviews"`
+	Category        string        `json:"category"`
+	Phone           string        `json:"phone"`
+	Website         string        `json:"website"`
+	Week            []DayJSON     `json:"week,omitempty"`
+	Tomorrow        *ForecastJSON `json:"tomorrow,omitempty"`
+}
+
+// DayJSON es un día del horario semanal
+type DayJSON struct {
+	Day       string         `json:"day"`
+	Known     bool           `json:"known"`
+	Closed    bool           `json:"closed"`
+	AllDay    bool           `json:"all_day"`
+	Intervals []IntervalJSON `json:"intervals"`
+}
+
+// IntervalJSON es un tramo de apertura (HH:MM)
+type IntervalJSON struct {
+	Opens  string `json:"opens"`
+	Closes string `json:"closes"`
+}
+
+// ForecastJSON es el estado previsto en otro momento (--tomorrow)
+type ForecastJSON struct {
+	Day    string `json:"day"`
+	Time   string `json:"time"`
+	Hours  string `json:"hours"`
+	Status Status `json:"status"`
+	Open   bool   `json:"open"`
+}
+
+// TickJSON es una comprobación de watch y notify
+type TickJSON struct {
+	SchemaVersion int    `json:"schema_version"`
+	Time          string `json:"time"`
+	Name          string `json:"name"`
+	Status        Status `json:"status"`
+	Open          bool   `json:"open"`
+	Hours         string `json:"hours"`
+}
+
+// EventJSON es el aviso de apertura que reciben el webhook y el FIFO de notify
+type EventJSON struct {
+	SchemaVersion int    `json:"schema_version"`
+	Event         string `json:"event"` // Siempre "open"
+	Title         string `json:"title"` // Título y mensaje del aviso, en el idioma de --lang
+	Message       string `json:"message"`
+	Name          string `json:"name"`
+	Address       string `json:"address"`
+	Hours         string `json:"hours"`
+	Time          string `json:"time"`
+}
+
+// BatchLineJSON es un negocio de batch
+type BatchLineJSON struct {
+	SchemaVersion int         `json:"schema_version"`
+	Business      string      `json:"business"`
+	City          string      `json:"city"`
+	At            string      `json:"at,omitempty"`
+	Result        *ResultJSON `json:"result"` // null si no hay resultados o la búsqueda falló
+	Error         string      `json:"error,omitempty"`
+}
+
+// BatchSummaryJSON es la última línea de batch
+type BatchSummaryJSON struct {
+	SchemaVersion int              `json:"schema_version"`
+	Summary       BatchSummaryKeys `json:"summary"`
+}
+
+// BatchSummaryKeys es el recuento de batch
+type BatchSummaryKeys struct {
+	Open    int `json:"open"`
+	Closed  int `json:"closed"`
+	Unknown int `json:"unknown"`
+	Errors  int `json:"errors"`
+}
+
+// JSONLanguages son los idiomas de las claves de la salida JSON (--json-lang)
+var JSONLanguages = []string{"en", "es"}
+
+// jsonKeysES son las claves en español. Las de la forma "padre.clave" solo
+// se aplican dentro de ese objeto; las claves que no aparecen no cambian.
+var jsonKeysES = map[string]string{
+	"schema_version":  "version_esquema",
+	"business":        "negocio",
+	"city":            "ciudad",
+	"at":              "momento",
+	"results":         "resultados",
+	"result":          "resultado",
+	"name":            "nombre",
+	"address":         "direccion",
+	"status":          "estado",
+	"open":            "abierto",
+	"hours":           "horario",
+	"hours_error":     "error_horario",
+	"timezone":        "zona_horaria",
+	"local_time":      "hora_local",
+	"holiday":         "festivo",
+	"holiday_name":    "festivo_nombre",
+	"uncertain":       "incierto",
+	"reviews":         "opiniones",
+	"category":        "categoria",
+	"phone":           "telefono",
+	"week":            "semana",
+	"tomorrow":        "manana",
+	"day":             "dia",
+	"time":            "hora",
+	"known":           "conocido",
+	"closed":          "cerrado",
+	"all_day":         "24h",
+	"intervals":       "tramos",
+	"opens":           "abre",
+	"closes":          "cierra",
+	"event":           "evento",
+	"title":           "titulo",
+	"message":         "mensaje",
+	"summary":         "resumen",
+	"summary.open":    "abiertos",
+	"summary.closed":  "cerrados",
+	"summary.unknown": "sin_horario",
+	"summary.errors":  "errores",
+
+	// Cuenta atrás hasta el cierre o la apertura
+	"closes_in_minutes": "cierra_en_minutos",
+	"opens_in_minutes":  "abre_en_minutos",
+}
+
+// jsonKey devuelve la clave key del objeto contenido en parent en el idioma lang
+func jsonKey(lang, parent, key string) string {
+	if lang != "es" {
+		return key
+	}
+	if k, ok := jsonKeysES[parent+"."+key]; ok {
+		return k
+	}
+	if k, ok := jsonKeysES[key]; ok {
+		return k
+	}
+	return key
+}
+
+// writeJSON imprime v con las claves en el idioma de --json-lang, indentado
+// o en una sola línea (NDJSON)
+func (f *Formatter) writeJSON(v interface{}, indent bool) {
+	data, err := f.marshalJSON(v)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error al generar JSON: %v\n", err)
+		return
+	}
+
+	if indent {
+		var buf bytes.Buffer
+		json.Indent(&buf, data, "", "  ")
+		data = buf.Bytes()
+	}
+	fmt.Println(string(data))
+}
+
+// marshalJSON codifica v con las claves en el idioma de --json-lang
+func (f *Formatter) marshalJSON(v interface{}) ([]byte, error) {
+	return MarshalJSON(v, f.JSONLang)
+}
+
+// MarshalJSON codifica v con las claves en el idioma lang ("en" o "es")
+func MarshalJSON(v interface{}, lang string) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return localizeJSON(data, lang)
+}
+
+// localizeJSON traduce las claves de un documento JSON conservando su orden
+func localizeJSON(data []byte, lang string) ([]byte, error) {
+	if lang == "" || lang == "en" {
+		return data, nil
+	}
+
+	// Cada objeto o array abierto guarda la clave (en inglés) que lo
+	// contiene y cuántos tokens lleva, para saber si toca una clave
+	type level struct {
+		object bool
+		key    string
+		count  int
+	}
+	var (
+		stack   []level
+		lastKey string
+		out     bytes.Buffer
+	)
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			// Un objeto o array sin cerrar es un documento cortado
+			if len(stack) > 0 {
+				return nil, io.ErrUnexpectedEOF
+			}
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if d, ok := tok.(json.Delim); ok && (d == '}' || d == ']') {
+			stack = stack[:len(stack)-1]
+			out.WriteByte(byte(d))
+			if len(stack) > 0 {
+				stack[len(stack)-1].count++
+			}
+			continue
+		}
+
+		isKey := false
+		parent := ""
+		if len(stack) > 0 {
+			top := &stack[len(stack)-1]
+			isKey = top.object && top.count%2 == 0
+			parent = top.key
+			if top.count > 0 {
+				if top.object && !isKey {
+					out.WriteByte(':')
+				} else {
+					out.WriteByte(',')
+				}
+			}
+		}
+
+		if d, ok := tok.(json.Delim); ok {
+			// Un objeto dentro de otro se nombra por su clave; dentro de un
+			// array, por la del array
+			key := parent
+			if len(stack) > 0 && stack[len(stack)-1].object {
+				key = lastKey
+			}
+			stack = append(stack, level{object: d == '{', key: key})
+			out.WriteByte(byte(d))
+			continue
+		}
+
+		if s, ok := tok.(string); ok && isKey {
+			lastKey = s
+			tok = jsonKey(lang, parent, s)
+		}
+		value, err := json.Marshal(tok)
+		if err != nil {
+			return nil, err
+		}
+		out.Write(value)
+		if len(stack) > 0 {
+			stack[len(stack)-1].count++
+		}
+	}
+
+	return out.Bytes(), nil
+}
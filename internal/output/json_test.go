@@ -0,0 +1,266 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// fullResult rellena todos los campos, también los omitempty, para que el
+// esquema tenga que describir cada clave
+var fullResult = ResultJSON{
+	Name:            "Bar Pepe",
+	Address:         "Calle Mayor 1, Madrid",
+	Status:          StatusOpen,
+	Open:            true,
+	Hours:           "10:00 - 14:00",
+	HoursError:      "Tiempo de espera agotado",
+	ClosesInMinutes: 90,
+	OpensInMinutes:  15,
+	TimeZone:        "Europe/Madrid",
+	LocalTime:       "2026-10-17T12:30:00+02:00",
+	Holiday:         true,
+	HolidayName:     "Fiesta Nacional",
+	Uncertain:       true,
+	Rating:          4.5,
+	Reviews:         120,
+	Category:        "bar",
+	Phone:           "+34 910 000 000",
+	Website:         "https://example.com",
+	Week: []DayJSON{
+		{Day: "lunes", Known: true, Intervals: []IntervalJSON{{Opens: "10:00", Closes: "14:00"}}},
+		{Day: "domingo", Known: true, Closed: true, Intervals: []IntervalJSON{}},
+	},
+	Tomorrow: &ForecastJSON{Day: "domingo", Time: "12:00", Hours: "cerrado", Status: StatusClosed},
+}
+
+// schemaSamples son documentos de ejemplo de cada esquema
+var schemaSamples = map[string][]interface{}{
+	"search": {
+		SearchJSON{SchemaVersion: SchemaVersion, Query: QueryJSON{Business: "bar", City: "madrid", At: "2026-10-17T12:30:00+02:00"}, Total: 1, Results: []ResultJSON{fullResult}},
+		SearchJSON{SchemaVersion: SchemaVersion, Query: QueryJSON{Business: "bar", City: "madrid"}, Results: []ResultJSON{}},
+	},
+	"ndjson": {
+		ResultLineJSON{SchemaVersion: SchemaVersion, ResultJSON: fullResult},
+	},
+	"batch": {
+		BatchLineJSON{SchemaVersion: SchemaVersion, Business: "bar", City: "madrid", At: "2026-10-17T12:30:00+02:00", Result: &fullResult},
+		BatchLineJSON{SchemaVersion: SchemaVersion, Business: "bar", City: "madrid", Error: "API Key inválida"},
+		BatchSummaryJSON{SchemaVersion: SchemaVersion, Summary: BatchSummaryKeys{Open: 2, Closed: 1, Unknown: 1, Errors: 1}},
+	},
+	"watch": {
+		TickJSON{SchemaVersion: SchemaVersion, Time: "2026-10-17T12:30:00+02:00", Name: "Bar Pepe", Status: StatusUnknown},
+	},
+	"notify": {
+		EventJSON{SchemaVersion: SchemaVersion, Event: "open", Title: "Bar Pepe ha abierto", Name: "Bar Pepe", Time: "2026-10-17T12:30:00+02:00"},
+	},
+}
+
+func TestSchemaDescribesOutput(t *testing.T) {
+	for _, lang := range JSONLanguages {
+		for _, name := range SchemaNames {
+			samples, ok := schemaSamples[name]
+			if !ok {
+				t.Errorf("sin ejemplos para el esquema %s", name)
+				continue
+			}
+			data, err := Schema(name, lang)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var schema map[string]interface{}
+			if err := json.Unmarshal(data, &schema); err != nil {
+				t.Fatal(err)
+			}
+
+			for i, sample := range samples {
+				doc, err := MarshalJSON(sample, lang)
+				if err != nil {
+					t.Fatal(err)
+				}
+				var value interface{}
+				if err := json.Unmarshal(doc, &value); err != nil {
+					t.Fatalf("%s %s #%d: JSON no válido %s: %v", lang, name, i, doc, err)
+				}
+				if err := validate(schema, value, "$"); err != nil {
+					t.Errorf("%s %s #%d: %v\n%s", lang, name, i, err, doc)
+				}
+			}
+		}
+	}
+}
+
+func TestSchemaUnknownDocument(t *testing.T) {
+	if _, err := Schema("tabla", "en"); err == nil {
+		t.Error("Schema(tabla) sin error")
+	}
+}
+
+// validate comprueba value contra el subconjunto de JSON Schema que genera
+// typeSchema: type, properties, required, additionalProperties, items,
+// anyOf, oneOf, enum y const
+func validate(schema map[string]interface{}, value interface{}, path string) error {
+	if variants, ok := schema["oneOf"].([]interface{}); ok {
+		return matchOne(variants, value, path, true)
+	}
+	if variants, ok := schema["anyOf"].([]interface{}); ok {
+		return matchOne(variants, value, path, false)
+	}
+	if c, ok := schema["const"]; ok && !reflect.DeepEqual(c, value) {
+		return fmt.Errorf("%s = %v, want %v", path, value, c)
+	}
+	if enum, ok := schema["enum"].([]interface{}); ok {
+		found := false
+		for _, e := range enum {
+			found = found || reflect.DeepEqual(e, value)
+		}
+		if !found {
+			return fmt.Errorf("%s = %v no está en %v", path, value, enum)
+		}
+	}
+
+	switch schema["type"] {
+	case "null":
+		if value != nil {
+			return fmt.Errorf("%s = %v, want null", path, value)
+		}
+	case "string":
+		if _, ok := value.(string); !ok {
+			return fmt.Errorf("%s = %v, want string", path, value)
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("%s = %v, want boolean", path, value)
+		}
+	case "number":
+		if _, ok := value.(float64); !ok {
+			return fmt.Errorf("%s = %v, want number", path, value)
+		}
+	case "integer":
+		if n, ok := value.(float64); !ok || n != float64(int64(n)) {
+			return fmt.Errorf("%s = %v, want integer", path, value)
+		}
+	case "array":
+		items, ok := value.([]interface{})
+		if !ok {
+			return fmt.Errorf("%s = %v, want array", path, value)
+		}
+		for i, item := range items {
+			if err := validate(schema["items"].(map[string]interface{}), item, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	case "object":
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("%s = %v, want object", path, value)
+		}
+		properties := schema["properties"].(map[string]interface{})
+		keys := make([]string, 0, len(object))
+		for key := range object {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			prop, ok := properties[key].(map[string]interface{})
+			if !ok {
+				return fmt.Errorf("%s: la clave %q no está en properties", path, key)
+			}
+			if err := validate(prop, object[key], path+"."+key); err != nil {
+				return err
+			}
+		}
+		for _, key := range schema["required"].([]interface{}) {
+			if _, ok := object[key.(string)]; !ok {
+				return fmt.Errorf("%s: falta la clave obligatoria %q", path, key)
+			}
+		}
+	default:
+		return fmt.Errorf("%s: esquema sin tipo: %v", path, schema)
+	}
+	return nil
+}
+
+// matchOne comprueba que value cumpla una variante (oneOf: exactamente una)
+func matchOne(variants []interface{}, value interface{}, path string, exactlyOne bool) error {
+	matches := 0
+	var lastErr error
+	for _, v := range variants {
+		if err := validate(v.(map[string]interface{}), value, path); err != nil {
+			lastErr = err
+		} else {
+			matches++
+		}
+	}
+	switch {
+	case matches == 0:
+		return fmt.Errorf("%s no cumple ninguna variante: %v", path, lastErr)
+	case exactlyOne && matches > 1:
+		return fmt.Errorf("%s cumple %d variantes de oneOf", path, matches)
+	}
+	return nil
+}
+
+func TestLocalizeJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{
+			name: "claves de primer nivel y orden",
+			in:   `{"schema_version":1,"name":"Bar","address":"Calle 1","status":"open","open":true}`,
+			want: `{"version_esquema":1,"nombre":"Bar","direccion":"Calle 1","estado":"open","abierto":true}`,
+		},
+		{
+			name: "las claves con padre solo cambian dentro de él",
+			in:   `{"summary":{"open":2,"closed":1,"unknown":0,"errors":3},"result":{"open":true,"closed":false}}`,
+			want: `{"resumen":{"abiertos":2,"cerrados":1,"sin_horario":0,"errores":3},"resultado":{"abierto":true,"cerrado":false}}`,
+		},
+		{
+			name: "objetos dentro de arrays",
+			in:   `{"results":[{"name":"A","week":[{"day":"lunes","closed":true,"intervals":[{"opens":"10:00","closes":"14:00"}]}]},{"name":"B","week":[]}]}`,
+			want: `{"resultados":[{"nombre":"A","semana":[{"dia":"lunes","cerrado":true,"tramos":[{"abre":"10:00","cierra":"14:00"}]}]},{"nombre":"B","semana":[]}]}`,
+		},
+		{
+			name: "los valores no se traducen",
+			in:   `{"status":"closed","hours":"open","category":"name","tomorrow":null}`,
+			want: `{"estado":"closed","horario":"open","categoria":"name","manana":null}`,
+		},
+		{
+			name: "números, escapes y claves desconocidas",
+			in:   `{"rating":4.5,"reviews":1200,"phone":"+34 \"910\"","website":"https://x.es/?a=1\u0026b=2","extra":{"open":1}}`,
+			want: `{"rating":4.5,"opiniones":1200,"telefono":"+34 \"910\"","website":"https://x.es/?a=1\u0026b=2","extra":{"abierto":1}}`,
+		},
+		{
+			name: "all_day",
+			in:   `{"all_day":true,"known":false}`,
+			want: `{"24h":true,"conocido":false}`,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := localizeJSON([]byte(tc.in), "es")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tc.want {
+				t.Errorf("localizeJSON\n got: %s\nwant: %s", got, tc.want)
+			}
+			// En inglés no cambia nada
+			if en, _ := localizeJSON([]byte(tc.in), "en"); string(en) != tc.in {
+				t.Errorf("localizeJSON en = %s", en)
+			}
+		})
+	}
+}
+
+func TestLocalizeJSONInvalid(t *testing.T) {
+	for _, in := range []string{`{"name":`, `{"results":[{"name":"A"}`, `{"name" "A"}`} {
+		if got, err := localizeJSON([]byte(in), "es"); err == nil {
+			t.Errorf("localizeJSON(%s) = %s sin error", in, got)
+		}
+	}
+}
@@ -1,13 +1,16 @@
 package output
 
 import (
-	"encoding/json"
+	"errors"
 	"fmt"
-	"os"
+	"strings"
+	"text/template"
 	"time"
+	"unicode/utf8"
 
 	"github.com/686f6c61/pingbar/internal/api"
 	"github.com/686f6c61/pingbar/internal/i18n"
+	"github.com/686f6c61/pingbar/internal/schedule"
 	"github.com/fatih/color"
 )
 
@@ -15,14 +18,26 @@ import (
 type Formatter struct {
 	Lang      i18n.Lang
 	UseColors bool
-	JSONMode  bool
+	Format    string             // Formato de salida registrado (--format)
+	JSONLang  string             // Idioma de las claves JSON (--json-lang): "en" o "es"
+	Template  *template.Template // Plantilla de cada resultado (--template)
+	Verbose   bool               // Explicar por qué falta el horario (--verbose)
+	At        time.Time          // Instante de --at; sin valor, el estado es el actual
 }
 
-// NewFormatter crea un nuevo formateador
-func NewFormatter(lang string, colorMode string, jsonMode bool) *Formatter {
+// NewFormatter crea un nuevo formateador. format es uno de los formatos
+// registrados; vacío para DefaultFormat.
+func NewFormatter(lang string, colorMode string, format string) (*Formatter, error) {
+	if format == "" {
+		format = DefaultFormat
+	}
+	if _, ok := formats[format]; !ok {
+		return nil, fmt.Errorf("formato no válido: %s (usa %s)", format, strings.Join(Formats(), ", "))
+	}
+
 	f := &Formatter{
-		Lang:     i18n.Lang(lang),
-		JSONMode: jsonMode,
+		Lang:   i18n.Lang(lang),
+		Format: format,
 	}
 
 	switch colorMode {
@@ -35,53 +50,111 @@ func NewFormatter(lang string, colorMode string, jsonMode bool) *Formatter {
 		f.UseColors = !color.NoColor
 	}
 
-	return f
+	return f, nil
 }
 
-// PrintResults imprime los resultados
+// PrintResults imprime los resultados en el formato del formateador
 func (f *Formatter) PrintResults(results []api.BusinessInfo, business, city string, showWeek bool) {
-	if f.JSONMode {
-		f.printJSON(results, business, city)
-		return
-	}
-	f.printText(results, business, city)
+	formats[f.Format](f, results, business, city, showWeek)
 }
 
-func (f *Formatter) printJSON(results []api.BusinessInfo, business, city string) {
-	jsonResults := make([]map[string]interface{}, 0, len(results))
+func (f *Formatter) printJSON(results []api.BusinessInfo, business, city string, showWeek bool) {
+	f.writeJSON(f.searchJSON(results, business, city, showWeek), true)
+}
 
+// searchJSON construye el documento JSON de una búsqueda
+func (f *Formatter) searchJSON(results []api.BusinessInfo, business, city string, showWeek bool) SearchJSON {
+	output := SearchJSON{
+		SchemaVersion: SchemaVersion,
+		Query:         QueryJSON{Business: business, City: city},
+		Total:         len(results),
+		Results:       make([]ResultJSON, 0, len(results)),
+	}
+	if !f.At.IsZero() {
+		output.Query.At = f.At.Format(time.RFC3339)
+	}
 	for _, r := range results {
-		item := map[string]interface{}{
-			"nombre":    r.Name,
-			"direccion": r.Address,
-			"rating":    r.Rating,
-			"opiniones": r.RatingCount,
-			"categoria": r.Category,
-			"telefono":  r.Phone,
-			"website":   r.Website,
-			"abierto":   r.IsOpen,
+		output.Results = append(output.Results, f.resultJSON(r, showWeek))
+	}
+	return output
+}
+
+// resultJSON convierte un resultado en el objeto de la salida JSON
+func (f *Formatter) resultJSON(r api.BusinessInfo, showWeek bool) ResultJSON {
+	item := ResultJSON{
+		Name:        r.Name,
+		Address:     r.Address,
+		Status:      statusOf(r.IsOpen, r.IsUnknown),
+		Open:        r.IsOpen && !r.IsUnknown,
+		Hours:       r.HoursInfo,
+		Holiday:     r.Holiday != "",
+		HolidayName: r.Holiday,
+		Uncertain:   r.Holiday != "",
+		Rating:      r.Rating,
+		Reviews:     r.RatingCount,
+		Category:    r.Category,
+		Phone:       r.Phone,
+		Website:     r.Website,
+	}
+	if item.Hours == "" {
+		item.Hours = DayHours(i18n.Get(f.Lang), r.Today, "")
+	}
+	if r.HoursError != nil {
+		item.HoursError = r.HoursError.Error()
+	}
+	if !r.At.IsZero() {
+		item.TimeZone = r.At.Location().String()
+		item.LocalTime = r.At.Format(time.RFC3339)
+	}
+	if c := r.Countdown; c != nil {
+		if c.Open && c.ClosesIn > 0 {
+			item.ClosesInMinutes = int(c.ClosesIn.Minutes())
 		}
-		if r.HoursInfo != "" {
-			item["horario"] = r.HoursInfo
+		if !c.Open && c.OpensIn > 0 {
+			item.OpensInMinutes = int(c.OpensIn.Minutes())
 		}
-		jsonResults = append(jsonResults, item)
 	}
+	if showWeek && r.Schedule != nil {
+		item.Week = f.weekJSON(r.Schedule)
+	}
+	if r.Tomorrow != nil {
+		item.Tomorrow = &ForecastJSON{
+			Day:    i18n.GetDay(f.Lang, int(r.Tomorrow.At.Weekday())),
+			Time:   r.Tomorrow.At.Format("15:04"),
+			Hours:  DayHours(i18n.Get(f.Lang), r.Tomorrow.Day, r.Tomorrow.Hours),
+			Status: statusOf(r.Tomorrow.IsOpen, r.Tomorrow.IsUnknown),
+			Open:   r.Tomorrow.IsOpen && !r.Tomorrow.IsUnknown,
+		}
+	}
+	return item
+}
 
-	output := map[string]interface{}{
-		"query": map[string]string{
-			"negocio": business,
-			"ciudad":  city,
-		},
-		"total":      len(results),
-		"resultados": jsonResults,
+// weekJSON convierte el horario semanal en un array de 7 días, de lunes a domingo
+func (f *Formatter) weekJSON(week *schedule.Week) []DayJSON {
+	days := make([]DayJSON, 0, len(schedule.WeekOrder))
+
+	for _, wd := range schedule.WeekOrder {
+		day := week.Day(wd)
+		intervals := make([]IntervalJSON, 0, len(day.Intervals))
+		for _, iv := range day.Intervals {
+			intervals = append(intervals, IntervalJSON{
+				Opens:  schedule.FormatMinutes(iv.Open),
+				Closes: schedule.FormatMinutes(iv.Close),
+			})
+		}
+		days = append(days, DayJSON{
+			Day:       i18n.GetDay(f.Lang, int(wd)),
+			Known:     day.Known,
+			Closed:    day.Closed,
+			AllDay:    day.AllDay,
+			Intervals: intervals,
+		})
 	}
 
-	encoder := json.NewEncoder(os.Stdout)
-	encoder.SetIndent("", "  ")
-	encoder.Encode(output)
+	return days
 }
 
-func (f *Formatter) printText(results []api.BusinessInfo, business, city string) {
+func (f *Formatter) printText(results []api.BusinessInfo, business, city string, showWeek bool) {
 	msgs := i18n.Get(f.Lang)
 
 	if len(results) == 0 {
@@ -89,19 +162,23 @@ func (f *Formatter) printText(results []api.BusinessInfo, business, city string)
 		return
 	}
 
+	if !f.At.IsZero() {
+		fmt.Printf(msgs.AtHeader+"\n\n", msgs.Days[int(f.At.Weekday())], f.At.Format("02/01/2006"), f.At.Format("15:04"))
+	}
+
 	if len(results) > 1 {
 		fmt.Printf(msgs.Found+"\n\n", len(results))
 	}
 
 	for i, r := range results {
-		f.printBusinessInfo(r)
+		f.printBusinessInfo(r, showWeek)
 		if i < len(results)-1 {
 			fmt.Println()
 		}
 	}
 }
 
-func (f *Formatter) printBusinessInfo(info api.BusinessInfo) {
+func (f *Formatter) printBusinessInfo(info api.BusinessInfo, showWeek bool) {
 	msgs := i18n.Get(f.Lang)
 	green := color.New(color.FgGreen, color.Bold)
 	red := color.New(color.FgRed, color.Bold)
@@ -124,8 +201,17 @@ func (f *Formatter) printBusinessInfo(info api.BusinessInfo) {
 		statusText = msgs.Closed
 	}
 
-	// Primera línea: [ESTADO] Nombre - Dirección
+	// En festivo el horario habitual puede no aplicarse
+	if info.Holiday != "" && !info.IsUnknown {
+		statusColor = yellow
+		statusText += "?"
+	}
+
+	// Primera línea: [ESTADO] (cuenta atrás) Nombre - Dirección
 	statusColor.Printf("[%s] ", statusText)
+	if countdown := f.countdownText(info.Countdown); countdown != "" {
+		gray.Printf("(%s) ", countdown)
+	}
 	white.Printf("%s", info.Name)
 	if info.Address != "" {
 		fmt.Printf(" - %s", info.Address)
@@ -135,12 +221,32 @@ func (f *Formatter) printBusinessInfo(info api.BusinessInfo) {
 	indent := "          "
 
 	// Mostrar horario si está disponible
-	if info.HoursInfo != "" {
-		now := time.Now()
-		dayName := msgs.Days[int(now.Weekday())]
-		fmt.Printf("%s%s %s: %s\n", indent, msgs.Today, dayName, info.HoursInfo)
+	if hours := DayHours(msgs, info.Today, info.TodayHours); hours != "" {
+		fmt.Printf("%s%s: %s\n", indent, f.dayLabel(info.At), hours)
 	} else {
 		gray.Printf("%s%s\n", indent, msgs.NoSchedule)
+		if f.Verbose {
+			gray.Printf("%s%s\n", indent, f.hoursDiagnostic(info))
+		}
+	}
+
+	// Avisar si hoy es festivo
+	if info.Holiday != "" {
+		holiday := msgs.Holiday
+		if !f.At.IsZero() {
+			holiday = msgs.HolidayAt
+		}
+		yellow.Printf("%s%s (%s)\n", indent, holiday, info.Holiday)
+	}
+
+	// Mostrar previsión para mañana
+	if info.Tomorrow != nil {
+		f.printTomorrow(info.Tomorrow, indent)
+	}
+
+	// Mostrar horario semanal
+	if showWeek && info.Schedule != nil {
+		f.printWeek(info.Schedule, indent, evaluatedAt(info).Weekday())
 	}
 
 	// Mostrar rating si existe
@@ -171,6 +277,207 @@ func (f *Formatter) printBusinessInfo(info api.BusinessInfo) {
 	}
 }
 
+// PrintTick imprime una comprobación del modo watch: hora, estado y horario
+// del día. En JSON imprime un objeto por línea y con --template, la plantilla
+// con el estado de la comprobación.
+func (f *Formatter) PrintTick(info api.BusinessInfo, forecast api.Forecast) {
+	if f.Format == "template" {
+		f.executeTemplate(f.tickData(info, forecast))
+		return
+	}
+	if f.JSONLines() {
+		f.writeJSON(TickJSON{
+			SchemaVersion: SchemaVersion,
+			Time:          forecast.At.Format(time.RFC3339),
+			Name:          info.Name,
+			Status:        statusOf(forecast.IsOpen, forecast.IsUnknown),
+			Open:          forecast.IsOpen && !forecast.IsUnknown,
+			Hours:         DayHours(i18n.Get(f.Lang), forecast.Day, forecast.Hours),
+		}, false)
+		return
+	}
+
+	msgs := i18n.Get(f.Lang)
+	gray := color.New(color.FgHiBlack)
+
+	statusColor := color.New(color.FgRed, color.Bold)
+	statusText := msgs.Closed
+	switch {
+	case forecast.IsUnknown:
+		statusColor = color.New(color.FgYellow, color.Bold)
+		statusText = msgs.Unknown
+	case forecast.IsOpen:
+		statusColor = color.New(color.FgGreen, color.Bold)
+		statusText = msgs.Open
+	}
+
+	gray.Printf("%s ", forecast.At.Format("15:04:05"))
+	statusColor.Printf("[%s] ", statusText)
+	fmt.Print(info.Name)
+	if hours := DayHours(msgs, forecast.Day, forecast.Hours); hours != "" {
+		gray.Printf(" (%s)", hours)
+	}
+	fmt.Println()
+}
+
+// DayHours devuelve el horario de un día para mostrarlo: "cerrado" o
+// "abierto 24 horas" en el idioma de msgs si el horario semanal lo dice, o
+// si no hours (los tramos o el horario en texto libre)
+func DayHours(msgs i18n.Messages, day schedule.Day, hours string) string {
+	switch {
+	case day.Known && day.AllDay:
+		return msgs.OpenAllDay
+	case day.Known && day.Closed:
+		return msgs.ClosedDay
+	}
+	return hours
+}
+
+// dayLabel nombra el día en el que se evalúa el estado: "Hoy sábado" o,
+// con --at, "domingo 18/10". Si el negocio está en otra zona horaria que el
+// sistema, añade la hora local usada: "Hoy sábado (hora local 17:02, Atlantic/Canary)".
+func (f *Formatter) dayLabel(at time.Time) string {
+	msgs := i18n.Get(f.Lang)
+	if at.IsZero() {
+		at = time.Now()
+	}
+
+	label := fmt.Sprintf("%s %s", msgs.Today, msgs.Days[int(at.Weekday())])
+	if !f.At.IsZero() {
+		label = fmt.Sprintf("%s %s", msgs.Days[int(at.Weekday())], at.Format("02/01"))
+	}
+
+	_, offset := at.Zone()
+	_, systemOffset := at.In(time.Local).Zone()
+	if offset != systemOffset {
+		label += fmt.Sprintf(" ("+msgs.LocalTime+")", at.Format("15:04"), at.Location())
+	}
+	return label
+}
+
+// evaluatedAt devuelve el instante en el que se evaluó el estado del negocio
+func evaluatedAt(info api.BusinessInfo) time.Time {
+	if info.At.IsZero() {
+		return time.Now()
+	}
+	return info.At
+}
+
+// hoursDiagnostic explica por qué no hay horario: la consulta falló, no se
+// hizo o el negocio no lo publica
+func (f *Formatter) hoursDiagnostic(info api.BusinessInfo) string {
+	msgs := i18n.Get(f.Lang)
+
+	switch {
+	case info.HoursError != nil:
+		return fmt.Sprintf(msgs.HoursLookupFailed, info.HoursError)
+	case !info.HoursChecked:
+		return msgs.HoursNotChecked
+	default:
+		return msgs.HoursNotPublished
+	}
+}
+
+// countdownText describe el tiempo hasta el cierre, o desde el cierre y hasta la apertura
+func (f *Formatter) countdownText(c *schedule.Countdown) string {
+	if c == nil {
+		return ""
+	}
+	msgs := i18n.Get(f.Lang)
+
+	if c.Open {
+		if c.ClosesIn > 0 {
+			return fmt.Sprintf(msgs.ClosesIn, formatDuration(c.ClosesIn))
+		}
+		return ""
+	}
+
+	parts := make([]string, 0, 2)
+	if c.ClosedAgo > 0 {
+		parts = append(parts, fmt.Sprintf(msgs.ClosedAgo, formatDuration(c.ClosedAgo)))
+	}
+	if c.OpensIn > 0 {
+		parts = append(parts, fmt.Sprintf(msgs.OpensIn, formatDuration(c.OpensIn)))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// formatDuration da un formato corto a una duración ("45min", "2h 15min", "1d 3h")
+func formatDuration(d time.Duration) string {
+	mins := int(d.Minutes())
+	days, hours, mins := mins/(24*60), mins/60%24, mins%60
+
+	switch {
+	case days > 0:
+		return fmt.Sprintf("%dd %dh", days, hours)
+	case hours > 0 && mins > 0:
+		return fmt.Sprintf("%dh %dmin", hours, mins)
+	case hours > 0:
+		return fmt.Sprintf("%dh", hours)
+	default:
+		return fmt.Sprintf("%dmin", mins)
+	}
+}
+
+// printTomorrow imprime el horario de mañana y el estado previsto a la hora elegida
+func (f *Formatter) printTomorrow(forecast *api.Forecast, indent string) {
+	msgs := i18n.Get(f.Lang)
+	gray := color.New(color.FgHiBlack)
+	dayName := msgs.Days[int(forecast.At.Weekday())]
+
+	if forecast.IsUnknown {
+		gray.Printf("%s%s %s: %s\n", indent, msgs.Tomorrow, dayName, msgs.NoSchedule)
+		return
+	}
+
+	statusColor := color.New(color.FgRed, color.Bold)
+	statusText := msgs.Closed
+	if forecast.IsOpen {
+		statusColor = color.New(color.FgGreen, color.Bold)
+		statusText = msgs.Open
+	}
+
+	fmt.Printf("%s%s %s: %s (", indent, msgs.Tomorrow, dayName, DayHours(msgs, forecast.Day, forecast.Hours))
+	fmt.Printf(msgs.AtTime+": ", forecast.At.Format("15:04"))
+	statusColor.Print(statusText)
+	fmt.Println(")")
+}
+
+// printWeek imprime el horario semanal como una tabla de 7 filas, con el
+// día today resaltado
+func (f *Formatter) printWeek(week *schedule.Week, indent string, today time.Weekday) {
+	msgs := i18n.Get(f.Lang)
+	bold := color.New(color.Bold)
+	gray := color.New(color.FgHiBlack)
+
+	fmt.Printf("%s%s\n", indent, msgs.WeekSchedule)
+	for _, wd := range schedule.WeekOrder {
+		day := week.Day(wd)
+
+		hours := DayHours(msgs, day, day.Hours())
+		if !day.Known {
+			hours = "--:--"
+		}
+
+		row := fmt.Sprintf("%s  %s %s", indent, padRight(i18n.GetDay(f.Lang, int(wd)), 10), hours)
+		if wd == today {
+			bold.Println(row)
+		} else if !day.Known {
+			gray.Println(row)
+		} else {
+			fmt.Println(row)
+		}
+	}
+}
+
+// padRight rellena con espacios contando caracteres, no bytes
+func padRight(s string, width int) string {
+	if n := utf8.RuneCountInString(s); n < width {
+		return s + strings.Repeat(" ", width-n)
+	}
+	return s
+}
+
 // PrintWelcome imprime el mensaje de bienvenida
 func PrintWelcome(lang string) {
 	msgs := i18n.Get(i18n.Lang(lang))
@@ -184,26 +491,43 @@ func PrintWelcome(lang string) {
 	fmt.Println(msgs.MoreInfo)
 }
 
-// PrintError imprime un mensaje de error
-func PrintError(errType, lang string) {
-	msgs := i18n.Get(i18n.Lang(lang))
+// PrintError imprime un mensaje de error. Los errores de la API se
+// traducen según su tipo; el resto se imprime tal cual.
+func PrintError(err error, lang string) {
 	red := color.New(color.FgRed)
+	red.Println(errorMessage(err, i18n.Get(i18n.Lang(lang))))
+}
 
-	var msg string
-	switch errType {
-	case "no_api_key":
-		msg = msgs.ErrorNoAPIKey
-	case "invalid_key":
-		msg = msgs.ErrorInvalidKey
-	case "connection":
-		msg = msgs.ErrorNoConnection
-	case "limit_reached":
-		msg = msgs.ErrorLimitReached
-	default:
-		msg = errType
+// errorMessage traduce un error de la API según su tipo
+func errorMessage(err error, msgs i18n.Messages) string {
+	var apiErr *api.APIError
+	if !errors.As(err, &apiErr) {
+		return err.Error()
 	}
 
-	red.Println(msg)
+	switch apiErr.Type {
+	case api.ErrorNoAPIKey:
+		return msgs.ErrorNoAPIKey
+	case api.ErrorInvalidKey:
+		return msgs.ErrorInvalidKey
+	case api.ErrorConnection:
+		return msgs.ErrorNoConnection
+	case api.ErrorLimitReached:
+		return msgs.ErrorLimitReached
+	case api.ErrorTimeout:
+		return msgs.ErrorTimeout
+	case api.ErrorCancelled:
+		return msgs.ErrorCancelled
+	case api.ErrorRateLimited:
+		return msgs.ErrorRateLimited
+	case api.ErrorServer:
+		return msgs.ErrorServer
+	case api.ErrorInvalidResponse:
+		return msgs.ErrorInvalidResponse
+	case api.ErrorInvalidRequest:
+		return msgs.ErrorInvalidRequest
+	}
+	return err.Error()
 }
 
 // PrintAbout imprime la información sobre el programa
@@ -0,0 +1,67 @@
+package output
+
+import (
+	"testing"
+	"time"
+
+	"github.com/686f6c61/pingbar/internal/api"
+	"github.com/686f6c61/pingbar/internal/i18n"
+	"github.com/686f6c61/pingbar/internal/schedule"
+)
+
+var (
+	closedDay = schedule.Day{Known: true, Closed: true}
+	allDay    = schedule.Day{Known: true, AllDay: true}
+	shiftDay  = schedule.Day{Known: true, Intervals: []schedule.Interval{{Open: 600, Close: 840}}}
+)
+
+func TestDayHours(t *testing.T) {
+	tests := []struct {
+		lang  i18n.Lang
+		day   schedule.Day
+		hours string
+		want  string
+	}{
+		{i18n.ES, closedDay, "", "cerrado"},
+		{i18n.EN, closedDay, "", "closed"},
+		{i18n.ES, allDay, "", "abierto 24 horas"},
+		{i18n.EN, allDay, "", "open 24 hours"},
+		{i18n.EN, shiftDay, "10:00 - 14:00", "10:00 - 14:00"},
+		// Sin horario semanal se muestra el texto libre tal cual
+		{i18n.EN, schedule.Day{}, "de lunes a sábado", "de lunes a sábado"},
+		{i18n.EN, schedule.Day{}, "", ""},
+	}
+	for _, tc := range tests {
+		if got := DayHours(i18n.Get(tc.lang), tc.day, tc.hours); got != tc.want {
+			t.Errorf("DayHours(%s, %+v, %q) = %q, want %q", tc.lang, tc.day, tc.hours, got, tc.want)
+		}
+	}
+}
+
+func TestResultJSONHoursFollowLang(t *testing.T) {
+	at := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	info := api.BusinessInfo{
+		Name:     "Bar Pepe",
+		Today:    closedDay,
+		At:       at,
+		Tomorrow: &api.Forecast{At: at.AddDate(0, 0, 1), Day: allDay, IsOpen: true},
+	}
+
+	for _, tc := range []struct {
+		lang     string
+		today    string
+		tomorrow string
+	}{
+		{"en", "closed", "open 24 hours"},
+		{"es", "cerrado", "abierto 24 horas"},
+	} {
+		f, err := NewFormatter(tc.lang, "off", "json")
+		if err != nil {
+			t.Fatal(err)
+		}
+		item := f.resultJSON(info, false)
+		if item.Hours != tc.today || item.Tomorrow.Hours != tc.tomorrow {
+			t.Errorf("--lang %s: hours %q, tomorrow %q; want %q, %q", tc.lang, item.Hours, item.Tomorrow.Hours, tc.today, tc.tomorrow)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// schemaDocuments son los documentos JSON que imprime pingbar, por nombre
+var schemaDocuments = map[string]struct {
+	title string
+	types []reflect.Type // Más de un tipo: cada línea es uno de ellos
+}{
+	"search": {"pingbar search", []reflect.Type{reflect.TypeOf(SearchJSON{})}},
+	"ndjson": {"pingbar search --format ndjson", []reflect.Type{reflect.TypeOf(ResultLineJSON{})}},
+	"batch":  {"pingbar batch (NDJSON)", []reflect.Type{reflect.TypeOf(BatchLineJSON{}), reflect.TypeOf(BatchSummaryJSON{})}},
+	"watch":  {"pingbar watch / notify (NDJSON)", []reflect.Type{reflect.TypeOf(TickJSON{})}},
+	"notify": {"pingbar notify (webhook, notify-fifo)", []reflect.Type{reflect.TypeOf(EventJSON{})}},
+}
+
+// SchemaNames son los documentos con JSON Schema, en el orden de la ayuda
+var SchemaNames = []string{"search", "ndjson", "batch", "watch", "notify"}
+
+// Schema devuelve el JSON Schema (draft 2020-12) del documento name con las
+// claves en el idioma lang. Se genera a partir de los tipos de la salida,
+// así que no puede desincronizarse de ella.
+func Schema(name, lang string) ([]byte, error) {
+	doc, ok := schemaDocuments[name]
+	if !ok {
+		return nil, fmt.Errorf("documento no válido: %s (usa %s)", name, strings.Join(SchemaNames, ", "))
+	}
+
+	schema := map[string]interface{}{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"title":   fmt.Sprintf("%s, schema_version %d", doc.title, SchemaVersion),
+	}
+	if len(doc.types) == 1 {
+		for k, v := range typeSchema(doc.types[0], lang, "") {
+			schema[k] = v
+		}
+	} else {
+		variants := make([]interface{}, 0, len(doc.types))
+		for _, t := range doc.types {
+			variants = append(variants, typeSchema(t, lang, ""))
+		}
+		schema["oneOf"] = variants
+	}
+
+	return json.MarshalIndent(schema, "", "  ")
+}
+
+var statusType = reflect.TypeOf(Status(""))
+
+// typeSchema describe un tipo de la salida. parent es la clave (en inglés)
+// que lo contiene, para traducir sus claves.
+func typeSchema(t reflect.Type, lang, parent string) map[string]interface{} {
+	if t == statusType {
+		return map[string]interface{}{
+			"type": "string",
+			"enum": []Status{StatusOpen, StatusClosed, StatusUnknown},
+		}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.Slice:
+		return map[string]interface{}{"type": "array", "items": typeSchema(t.Elem(), lang, parent)}
+	case reflect.Ptr:
+		return map[string]interface{}{"anyOf": []interface{}{typeSchema(t.Elem(), lang, parent), map[string]interface{}{"type": "null"}}}
+	}
+
+	properties := map[string]interface{}{}
+	required := []string{}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		// Los campos de un struct embebido van al mismo nivel
+		if field.Anonymous {
+			embedded := typeSchema(field.Type, lang, parent)
+			for k, v := range embedded["properties"].(map[string]interface{}) {
+				properties[k] = v
+			}
+			required = append(required, embedded["required"].([]string)...)
+			continue
+		}
+
+		name, opts, _ := strings.Cut(field.Tag.Get("json"), ",")
+		key := jsonKey(lang, parent, name)
+
+		elem := field.Type
+		if elem.Kind() == reflect.Ptr && opts == "omitempty" {
+			elem = elem.Elem()
+		}
+		prop := typeSchema(elem, lang, name)
+		if name == "schema_version" {
+			prop["const"] = SchemaVersion
+		}
+		properties[key] = prop
+
+		if opts != "omitempty" {
+			required = append(required, key)
+		}
+	}
+
+	return map[string]interface{}{
+		"type":                 "object",
+		"properties":           properties,
+		"required":             required,
+		"additionalProperties": false,
+	}
+}
@@ -0,0 +1,190 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"text/template"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/686f6c61/pingbar/internal/api"
+	"github.com/686f6c61/pingbar/internal/i18n"
+)
+
+// TemplateData es lo que recibe la plantilla de --template por cada
+// resultado: los campos de api.BusinessInfo ({{.Name}}, {{.TodayHours}},
+// {{.Rating}}...) y los de la consulta y el estado
+type TemplateData struct {
+	api.BusinessInfo
+	Status     Status // open, closed o unknown
+	StatusText string // Estado traducido: "ABIERTO", "CERRADO"...
+	Business   string // Negocio buscado
+	City       string // Ciudad buscada
+	Index      int    // Posición del resultado, desde 1
+}
+
+// SetTemplate interpreta la plantilla con la que se imprime cada resultado
+// (--template) y selecciona el formato "template"
+func (f *Formatter) SetTemplate(text string) error {
+	tmpl, err := template.New("template").Funcs(f.templateFuncs()).Parse(text)
+	if err != nil {
+		return fmt.Errorf("plantilla no válida: %v", err)
+	}
+	f.Template = tmpl
+	f.Format = "template"
+	return nil
+}
+
+// printTemplate imprime cada resultado con la plantilla, uno por línea
+func (f *Formatter) printTemplate(results []api.BusinessInfo, business, city string, showWeek bool) {
+	for i, r := range results {
+		f.executeTemplate(f.templateData(r, business, city, i+1))
+	}
+}
+
+// executeTemplate imprime un resultado con la plantilla, terminado en salto
+// de línea si la plantilla no lo incluye
+func (f *Formatter) executeTemplate(data TemplateData) {
+	if f.Template == nil {
+		fmt.Fprintln(os.Stderr, "Error: el formato template necesita --template o --template-file")
+		return
+	}
+
+	var out strings.Builder
+	if err := f.Template.Execute(&out, data); err != nil {
+		fmt.Fprintf(os.Stderr, "Error en la plantilla: %v\n", err)
+		return
+	}
+	text := out.String()
+	if !strings.HasSuffix(text, "\n") {
+		text += "\n"
+	}
+	fmt.Print(text)
+}
+
+// templateData prepara un resultado para la plantilla
+func (f *Formatter) templateData(info api.BusinessInfo, business, city string, index int) TemplateData {
+	msgs := i18n.Get(f.Lang)
+
+	// {{.TodayHours}} nombra los días cerrados o de 24 horas en el idioma de salida
+	info.TodayHours = DayHours(msgs, info.Today, info.TodayHours)
+
+	data := TemplateData{
+		BusinessInfo: info,
+		Status:       statusOf(info.IsOpen, info.IsUnknown),
+		Business:     business,
+		City:         city,
+		Index:        index,
+	}
+	switch data.Status {
+	case StatusOpen:
+		data.StatusText = msgs.Open
+	case StatusClosed:
+		data.StatusText = msgs.Closed
+	default:
+		data.StatusText = strings.TrimSpace(msgs.Unknown)
+	}
+	return data
+}
+
+// tickData prepara una comprobación de watch para la plantilla: el negocio
+// con el estado y el horario del momento de la comprobación
+func (f *Formatter) tickData(info api.BusinessInfo, forecast api.Forecast) TemplateData {
+	info.At = forecast.At
+	info.IsOpen = forecast.IsOpen
+	info.IsUnknown = forecast.IsUnknown
+	info.TodayHours = forecast.Hours
+	info.Today = forecast.Day
+	info.Countdown = nil
+	if info.Schedule != nil && !forecast.IsUnknown {
+		if c := info.Schedule.CountdownAt(forecast.At); c.Open == forecast.IsOpen {
+			info.Countdown = &c
+		}
+	}
+	return f.templateData(info, "", "", 1)
+}
+
+// templateFuncs son las funciones disponibles en las plantillas
+func (f *Formatter) templateFuncs() template.FuncMap {
+	return template.FuncMap{
+		// {{stars .Rating}} -> "★★★★☆"
+		"stars": func(rating float64) string {
+			full := int(rating)
+			if full > 5 {
+				full = 5
+			}
+			if full < 0 {
+				full = 0
+			}
+			return strings.Repeat("★", full) + strings.Repeat("☆", 5-full)
+		},
+		// {{phone .Phone}} -> "914 18 88 00"
+		"phone": formatPhone,
+		// {{t "Open"}} -> el mensaje traducido de i18n.Messages
+		"t": func(key string) (string, error) {
+			field := reflect.ValueOf(i18n.Get(f.Lang)).FieldByName(key)
+			if !field.IsValid() || field.Kind() != reflect.String {
+				return "", fmt.Errorf("mensaje desconocido: %s", key)
+			}
+			return field.String(), nil
+		},
+		// {{day .At}} -> "sábado"
+		"day": func(t time.Time) string {
+			return i18n.GetDay(f.Lang, int(t.Weekday()))
+		},
+		// {{countdown .}} -> "cierra en 2h 15min"
+		"countdown": func(data TemplateData) string {
+			return f.countdownText(data.Countdown)
+		},
+		// {{duration .Countdown.OpensIn}} -> "2h 15min"
+		"duration": formatDuration,
+		"upper":    strings.ToUpper,
+		"lower":    strings.ToLower,
+		// {{truncate 20 .Name}} corta a 20 caracteres con "…"
+		"truncate": func(n int, s string) string {
+			if n <= 0 || utf8.RuneCountInString(s) <= n {
+				return s
+			}
+			return string([]rune(s)[:n-1]) + "…"
+		},
+		// {{default "-" .Phone}} usa "-" si el valor está vacío
+		"default": func(def string, value interface{}) string {
+			s := fmt.Sprint(value)
+			if value == nil || s == "" {
+				return def
+			}
+			return s
+		},
+		// {{json .Schedule}}
+		"json": func(v interface{}) (string, error) {
+			data, err := json.Marshal(v)
+			return string(data), err
+		},
+	}
+}
+
+// formatPhone agrupa las cifras de un teléfono: "914188800" -> "914 18 88 00"
+// y "+34914188800" -> "+34 914 18 88 00". Los números con otro número de
+// cifras se devuelven con los espacios normalizados.
+func formatPhone(phone string) string {
+	var digits strings.Builder
+	for _, r := range phone {
+		if unicode.IsDigit(r) {
+			digits.WriteRune(r)
+		}
+	}
+	d := digits.String()
+
+	prefix := ""
+	if strings.HasPrefix(strings.TrimSpace(phone), "+") && len(d) > 9 {
+		prefix, d = "+"+d[:len(d)-9]+" ", d[len(d)-9:]
+	}
+	if len(d) != 9 {
+		return strings.Join(strings.Fields(phone), " ")
+	}
+	return prefix + d[:3] + " " + d[3:5] + " " + d[5:7] + " " + d[7:]
+}
@@ -0,0 +1,351 @@
+package schedule
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/686f6c61/pingbar/internal/locale"
+)
+
+// atLanguages son los idiomas en los que se puede escribir el instante de --at
+var atLanguages = []string{"es", "en"}
+
+// Palabras que no aportan nada al instante ("el sábado a las 14:00")
+var atFillers = map[string]bool{
+	"el": true, "la": true, "las": true, "los": true, "a": true, "al": true,
+	"de": true, "del": true, "por": true, "en": true, "este": true, "esta": true,
+	"que": true, "viene": true,
+	"on": true, "at": true, "this": true, "the": true, "in": true, "of": true,
+}
+
+// Palabras que llevan el día de la semana a la semana siguiente si coincide con hoy
+var atNext = map[string]bool{
+	"próximo": true, "proximo": true, "próxima": true, "proxima": true, "next": true,
+}
+
+// Días relativos a hoy
+var atRelativeDays = map[string]int{
+	"hoy": 0, "today": 0, "tonight": 0,
+	"mañana": 1, "manana": 1, "tomorrow": 1,
+}
+
+// Partes del día, en minutos desde medianoche
+var atDayParts = map[string]int{
+	"morning": 10 * 60, "noon": 12 * 60, "midday": 12 * 60,
+	"afternoon": 17 * 60, "evening": 20 * 60, "night": 21 * 60, "tonight": 21 * 60,
+	"midnight": 0,
+	"mediodía": 14 * 60, "mediodia": 14 * 60, "tarde": 17 * 60, "noche": 21 * 60,
+	"medianoche": 0,
+}
+
+var atMonths = map[string]time.Month{
+	"enero": time.January, "febrero": time.February, "marzo": time.March,
+	"abril": time.April, "mayo": time.May, "junio": time.June, "julio": time.July,
+	"agosto": time.August, "septiembre": time.September, "setiembre": time.September,
+	"octubre": time.October, "noviembre": time.November, "diciembre": time.December,
+	"january": time.January, "february": time.February, "march": time.March,
+	"april": time.April, "may": time.May, "june": time.June, "july": time.July,
+	"august": time.August, "september": time.September, "october": time.October,
+	"november": time.November, "december": time.December,
+}
+
+var (
+	atISODate   = regexp.MustCompile(`^(\d{4})-(\d{1,2})-(\d{1,2})(?:t(.+))?$`)
+	atSlashDate = regexp.MustCompile(`^(\d{1,2})/(\d{1,2})(?:/(\d{2}|\d{4}))?$`)
+	atClock     = regexp.MustCompile(`^(\d{1,2})(?:[:.h](\d{2}))?(h|am|pm)?$`)
+	atDayMonth  = regexp.MustCompile(`^(\d{1,2})(?:st|nd|rd|th)?$`)
+)
+
+// ParseAt interpreta un instante escrito en español o en inglés, relativo
+// a now:
+//
+//	"2026-10-18 21:30", "18/10 21:30", "18 de octubre a las 9", "21:30",
+//	"sábado 14:00", "mañana por la tarde", "saturday 2pm", "next friday noon"
+//
+// Sin hora se usa la hora de now y sin día, el día de now. Un día de la
+// semana es el próximo que llegue: hoy si la hora aún no ha pasado.
+func ParseAt(text string, now time.Time) (time.Time, error) {
+	invalid := fmt.Errorf("fecha no válida: %s (usa, por ejemplo, \"2026-10-18 21:30\" o \"sábado 14:00\")", text)
+
+	var (
+		date     *time.Time
+		offset   = -1
+		weekday  = time.Weekday(-1)
+		next     bool
+		clock    = -1
+		dayPart  = -1
+		meridiem string
+	)
+
+	tokens := atTokens(text)
+	for i := 0; i < len(tokens); i++ {
+		tok := tokens[i]
+		prev := ""
+		if i > 0 {
+			prev = tokens[i-1]
+		}
+
+		// "por la mañana" es una parte del día, no el día siguiente
+		if (tok == "mañana" || tok == "manana") && prev == "la" {
+			dayPart = 10 * 60
+			continue
+		}
+		// "pasado mañana"
+		if tok == "pasado" && i+1 < len(tokens) && atRelativeDays[tokens[i+1]] == 1 {
+			offset = 2
+			i++
+			continue
+		}
+
+		if atFillers[tok] {
+			continue
+		}
+		if atNext[tok] {
+			next = true
+			continue
+		}
+		if tok == "am" || tok == "pm" {
+			meridiem = tok
+			continue
+		}
+		if d, ok := atRelativeDays[tok]; ok {
+			offset = d
+			if part, ok := atDayParts[tok]; ok {
+				dayPart = part
+			}
+			continue
+		}
+		if part, ok := atDayParts[tok]; ok {
+			dayPart = part
+			continue
+		}
+		if wd, ok := atWeekday(tok); ok {
+			weekday = wd
+			continue
+		}
+
+		// "18 de octubre", "october 18th"
+		if month, ok := atMonths[tok]; ok {
+			day := -1
+			back := i - 1
+			for back >= 0 && atFillers[tokens[back]] {
+				back--
+			}
+			if back >= 0 {
+				day = atMonthDay(tokens[back])
+			}
+			if j := atSkipFillers(tokens, i+1); day < 0 && j < len(tokens) {
+				if day = atMonthDay(tokens[j]); day >= 0 {
+					i = j
+				}
+			}
+			if day < 0 {
+				return time.Time{}, invalid
+			}
+			year := now.Year()
+			if j := atSkipFillers(tokens, i+1); j < len(tokens) && len(tokens[j]) == 4 {
+				if y, err := strconv.Atoi(tokens[j]); err == nil {
+					year = y
+					i = j
+				}
+			}
+			d, ok := atDate(year, int(month), day, now, year == now.Year())
+			if !ok {
+				return time.Time{}, invalid
+			}
+			date = &d
+			continue
+		}
+
+		if m := atISODate.FindStringSubmatch(tok); m != nil {
+			year, _ := strconv.Atoi(m[1])
+			month, _ := strconv.Atoi(m[2])
+			day, _ := strconv.Atoi(m[3])
+			d, ok := atDate(year, month, day, now, false)
+			if !ok {
+				return time.Time{}, invalid
+			}
+			date = &d
+			if m[4] != "" {
+				if clock = atClockMinutes(m[4], ""); clock < 0 {
+					return time.Time{}, invalid
+				}
+			}
+			continue
+		}
+		if m := atSlashDate.FindStringSubmatch(tok); m != nil {
+			day, _ := strconv.Atoi(m[1])
+			month, _ := strconv.Atoi(m[2])
+			year, guess := now.Year(), true
+			if m[3] != "" {
+				year, _ = strconv.Atoi(m[3])
+				if year < 100 {
+					year += 2000
+				}
+				guess = false
+			}
+			d, ok := atDate(year, month, day, now, guess)
+			if !ok {
+				return time.Time{}, invalid
+			}
+			date = &d
+			continue
+		}
+
+		if atClock.MatchString(tok) {
+			// El número de "18 de octubre" se resuelve al llegar al mes
+			if j := atSkipFillers(tokens, i+1); j < len(tokens) && atMonths[tokens[j]] != 0 && atMonthDay(tok) >= 0 {
+				continue
+			}
+			suffix := ""
+			if i+1 < len(tokens) && (tokens[i+1] == "am" || tokens[i+1] == "pm") {
+				suffix = tokens[i+1]
+				i++
+			}
+			if clock = atClockMinutes(tok, suffix); clock < 0 {
+				return time.Time{}, invalid
+			}
+			continue
+		}
+
+		return time.Time{}, invalid
+	}
+
+	// "a las 5 de la tarde": la parte del día indica si es por la tarde
+	if clock >= 0 && clock < 12*60 && meridiem == "" && dayPart >= 17*60 {
+		clock += 12 * 60
+	}
+	if clock < 0 && meridiem != "" {
+		return time.Time{}, invalid
+	}
+
+	minutes := now.Hour()*60 + now.Minute()
+	switch {
+	case clock >= 0:
+		minutes = clock
+	case dayPart >= 0:
+		minutes = dayPart
+	}
+
+	var day time.Time
+	switch {
+	case date != nil:
+		day = *date
+	case offset >= 0:
+		day = now.AddDate(0, 0, offset)
+	case weekday >= 0:
+		diff := (int(weekday) - int(now.Weekday()) + 7) % 7
+		if diff == 0 && (next || minutes < now.Hour()*60+now.Minute()) {
+			diff = 7
+		}
+		day = now.AddDate(0, 0, diff)
+	default:
+		day = now
+	}
+
+	return time.Date(day.Year(), day.Month(), day.Day(), minutes/60, minutes%60, 0, 0, now.Location()), nil
+}
+
+// atTokens separa el texto en palabras. "p.m." pasa a ser "pm" y "9pm" se
+// separa en "9" y "pm", como si se hubiera escrito "9 pm".
+func atTokens(text string) []string {
+	text = strings.ToLower(text)
+	text = strings.NewReplacer("a.m.", "am", "p.m.", "pm", ",", " ").Replace(text)
+
+	var tokens []string
+	for _, field := range strings.Fields(text) {
+		// "2pm" -> "2", "pm"
+		for _, m := range []string{"am", "pm"} {
+			if strings.HasSuffix(field, m) && len(field) > 2 && field[len(field)-3] >= '0' && field[len(field)-3] <= '9' {
+				tokens = append(tokens, strings.TrimSuffix(field, m))
+				field = m
+				break
+			}
+		}
+		tokens = append(tokens, field)
+	}
+	return tokens
+}
+
+// atWeekday reconoce un día de la semana en cualquiera de los idiomas de --at
+func atWeekday(word string) (time.Weekday, bool) {
+	for _, lang := range atLanguages {
+		keywords := locale.KeywordsFor(lang)
+		if wd, ok := keywords.Days[word]; ok {
+			return wd, true
+		}
+		if wd, ok := keywords.WeakDays[word]; ok {
+			return wd, true
+		}
+	}
+	return 0, false
+}
+
+// atClockMinutes convierte "21:30", "9.30", "14h", "14h30" o "2" con sufijo
+// "pm" en minutos desde medianoche, o -1 si no es una hora válida
+func atClockMinutes(tok, meridiem string) int {
+	m := atClock.FindStringSubmatch(tok)
+	if m == nil {
+		return -1
+	}
+	hour, _ := strconv.Atoi(m[1])
+	min := 0
+	if m[2] != "" {
+		min, _ = strconv.Atoi(m[2])
+	}
+	if m[3] == "am" || m[3] == "pm" {
+		meridiem = m[3]
+	}
+
+	if meridiem != "" {
+		if hour < 1 || hour > 12 {
+			return -1
+		}
+		hour %= 12
+		if meridiem == "pm" {
+			hour += 12
+		}
+	}
+	if hour > 23 || min > 59 {
+		return -1
+	}
+	return hour*60 + min
+}
+
+// atMonthDay devuelve el día del mes de "18" o "18th", o -1
+func atMonthDay(tok string) int {
+	m := atDayMonth.FindStringSubmatch(tok)
+	if m == nil {
+		return -1
+	}
+	day, _ := strconv.Atoi(m[1])
+	if day < 1 || day > 31 {
+		return -1
+	}
+	return day
+}
+
+// atSkipFillers devuelve la posición de la siguiente palabra con contenido
+func atSkipFillers(tokens []string, i int) int {
+	for i < len(tokens) && atFillers[tokens[i]] {
+		i++
+	}
+	return i
+}
+
+// atDate construye una fecha comprobando que existe. Con guessYear, una
+// fecha ya pasada se entiende del año siguiente ("18/01" en diciembre).
+func atDate(year, month, day int, now time.Time, guessYear bool) (time.Time, bool) {
+	d := time.Date(year, time.Month(month), day, 0, 0, 0, 0, now.Location())
+	if d.Day() != day || int(d.Month()) != month {
+		return time.Time{}, false
+	}
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	if guessYear && d.Before(today) {
+		d = d.AddDate(1, 0, 0)
+	}
+	return d, true
+}
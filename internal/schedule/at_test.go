@@ -0,0 +1,65 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseAt(t *testing.T) {
+	madrid, err := time.LoadLocation("Europe/Madrid")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Miércoles 14 de octubre de 2026, 12:00
+	now := time.Date(2026, 10, 14, 12, 0, 0, 0, madrid)
+	at := func(day, hour, min int) time.Time {
+		return time.Date(2026, 10, day, hour, min, 0, 0, madrid)
+	}
+
+	tests := []struct {
+		text string
+		want time.Time
+	}{
+		{"2026-10-18 21:30", at(18, 21, 30)},
+		{"18/10 21:30", at(18, 21, 30)},
+		{"18 de octubre a las 9", at(18, 9, 0)},
+		{"21:30", at(14, 21, 30)},
+		{"sábado 14:00", at(17, 14, 0)},
+		{"el sábado a las 14h", at(17, 14, 0)},
+		{"miércoles 18:00", at(14, 18, 0)},
+		{"miércoles 10:00", at(21, 10, 0)},
+		{"próximo miércoles 18:00", at(21, 18, 0)},
+		{"mañana por la tarde", at(15, 17, 0)},
+		{"mañana a mediodía", at(15, 14, 0)},
+		{"hoy por la noche", at(14, 21, 0)},
+		{"saturday 2pm", at(17, 14, 0)},
+		{"next friday noon", at(16, 12, 0)},
+		{"tomorrow morning", at(15, 10, 0)},
+		{"tonight", at(14, 21, 0)},
+		{"12am", at(14, 0, 0)},
+		{"12pm", at(14, 12, 0)},
+		{"tomorrow 7:30pm", at(15, 19, 30)},
+	}
+	for _, tc := range tests {
+		t.Run(tc.text, func(t *testing.T) {
+			got, err := ParseAt(tc.text, now)
+			if err != nil {
+				t.Fatalf("ParseAt(%q): %v", tc.text, err)
+			}
+			if !got.Equal(tc.want) {
+				t.Errorf("ParseAt(%q) = %s, want %s", tc.text, got.Format(time.RFC3339), tc.want.Format(time.RFC3339))
+			}
+		})
+	}
+}
+
+func TestParseAtInvalid(t *testing.T) {
+	now := time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC)
+	for _, text := range []string{"tomorrow 25:00", "sábado 14:75", "13pm", "31/02", "cuando sea"} {
+		t.Run(text, func(t *testing.T) {
+			if got, err := ParseAt(text, now); err == nil {
+				t.Errorf("ParseAt(%q) = %s, want error", text, got.Format(time.RFC3339))
+			}
+		})
+	}
+}
@@ -0,0 +1,801 @@
+package schedule
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Horas aproximadas para los tiempos variables de opening_hours
+// (sunrise, sunset, dawn, dusk), que dependen de la fecha y la posición
+const (
+	approxDawn    = 6*60 + 30
+	approxSunrise = 7 * 60
+	approxSunset  = 19 * 60
+	approxDusk    = 19*60 + 30
+)
+
+// OpeningHours es una especificación opening_hours de OpenStreetMap
+// (https://wiki.openstreetmap.org/wiki/Key:opening_hours/specification)
+type OpeningHours struct {
+	rules []ohRule
+}
+
+type ohRuleKind int
+
+const (
+	ohNormal     ohRuleKind = iota // Separada por ";": sustituye a las anteriores
+	ohAdditional                   // Separada por ",": se suma a la anterior
+	ohFallback                     // Separada por "||": solo si ninguna otra aplica
+)
+
+type ohState int
+
+const (
+	ohOpen ohState = iota
+	ohClosed
+	ohUnknown
+)
+
+type ohRule struct {
+	kind     ohRuleKind
+	years    []ohRange
+	months   []ohMonthRange
+	weeks    []ohRange
+	weekdays []ohWeekday
+	holiday  bool // PH
+	school   bool // SH (vacaciones escolares; no se conocen, nunca coincide)
+	allWeek  bool // 24/7
+	times    []Interval
+	state    ohState
+	comment  string
+}
+
+type ohRange struct {
+	from, to int
+}
+
+// ohMonthRange es un rango de fechas; Day a cero significa el mes entero
+type ohMonthRange struct {
+	fromMonth, fromDay int
+	toMonth, toDay     int
+}
+
+type ohWeekday struct {
+	from, to time.Weekday
+	nth      []ohRange // Semanas del mes ("Mo[1]", "Su[-1]"); vacío = todas
+}
+
+var ohWeekdays = map[string]time.Weekday{
+	"mo": time.Monday, "tu": time.Tuesday, "we": time.Wednesday, "th": time.Thursday,
+	"fr": time.Friday, "sa": time.Saturday, "su": time.Sunday,
+}
+
+var ohMonths = map[string]int{
+	"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+	"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+}
+
+type ohTokenKind int
+
+const (
+	ohWord ohTokenKind = iota
+	ohNumber
+	ohTime
+	ohComment
+	ohPunct
+)
+
+type ohToken struct {
+	kind ohTokenKind
+	text string // Palabras en minúsculas; signos tal cual
+	num  int    // ohNumber y ohTime (minutos)
+}
+
+// ParseOpeningHours interpreta una especificación opening_hours
+// ("Mo-Fr 09:00-14:00,17:00-20:00; Sa 10:00-14:00; PH off")
+func ParseOpeningHours(spec string) (*OpeningHours, error) {
+	tokens, err := ohTokenize(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	oh := &OpeningHours{}
+	kind := ohNormal
+	start := 0
+	for i := 0; i <= len(tokens); i++ {
+		sep := i == len(tokens)
+		next := kind
+		if !sep {
+			switch t := tokens[i]; {
+			case t.kind == ohPunct && t.text == ";":
+				sep, next = true, ohNormal
+			case t.kind == ohPunct && t.text == "||":
+				sep, next = true, ohFallback
+			case t.kind == ohPunct && t.text == "," && isAdditionalSeparator(tokens, i):
+				sep, next = true, ohAdditional
+			}
+		}
+		if !sep {
+			continue
+		}
+
+		if i > start {
+			rule, err := parseOHRule(tokens[start:i])
+			if err != nil {
+				return nil, err
+			}
+			rule.kind = kind
+			oh.rules = append(oh.rules, rule)
+		}
+		kind = next
+		start = i + 1
+	}
+
+	if len(oh.rules) == 0 {
+		return nil, fmt.Errorf("opening_hours vacío")
+	}
+
+	return oh, nil
+}
+
+// isAdditionalSeparator distingue la coma que separa reglas de la que separa
+// elementos de una lista ("Mo,We 10:00-12:00" frente a "Mo 10:00-12:00, We off")
+func isAdditionalSeparator(tokens []ohToken, i int) bool {
+	if i == 0 || i+1 >= len(tokens) {
+		return false
+	}
+	prev, next := tokens[i-1], tokens[i+1]
+
+	prevEndsRule := prev.kind == ohTime || prev.kind == ohComment ||
+		(prev.kind == ohPunct && prev.text == "+") ||
+		(prev.kind == ohWord && isOHModifier(prev.text)) ||
+		(prev.kind == ohWord && isOHVariableTime(prev.text)) ||
+		(prev.kind == ohPunct && prev.text == ")")
+
+	if !prevEndsRule {
+		return false
+	}
+
+	if next.kind == ohWord {
+		_, isDay := ohWeekdays[next.text]
+		_, isMonth := ohMonths[next.text]
+		return isDay || isMonth || next.text == "ph" || next.text == "sh" || next.text == "week"
+	}
+	return next.kind == ohNumber && next.num >= 1900
+}
+
+func isOHModifier(word string) bool {
+	return word == "open" || word == "closed" || word == "off" || word == "unknown"
+}
+
+func isOHVariableTime(word string) bool {
+	return word == "sunrise" || word == "sunset" || word == "dawn" || word == "dusk"
+}
+
+func ohTokenize(spec string) ([]ohToken, error) {
+	runes := []rune(strings.TrimSpace(spec))
+	var tokens []ohToken
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+
+		case r == '"':
+			end := i + 1
+			for end < len(runes) && runes[end] != '"' {
+				end++
+			}
+			if end >= len(runes) {
+				return nil, fmt.Errorf("comentario sin cerrar en opening_hours")
+			}
+			tokens = append(tokens, ohToken{kind: ohComment, text: string(runes[i+1 : end])})
+			i = end + 1
+
+		case unicode.IsDigit(r):
+			start := i
+			for i < len(runes) && unicode.IsDigit(runes[i]) {
+				i++
+			}
+			n, _ := strconv.Atoi(string(runes[start:i]))
+			if i+2 < len(runes) && runes[i] == ':' && unicode.IsDigit(runes[i+1]) && unicode.IsDigit(runes[i+2]) && i-start <= 2 {
+				mins, _ := strconv.Atoi(string(runes[i+1 : i+3]))
+				if n > 48 || mins >= 60 {
+					return nil, fmt.Errorf("hora no válida en opening_hours: %s", string(runes[start:i+3]))
+				}
+				tokens = append(tokens, ohToken{kind: ohTime, text: string(runes[start : i+3]), num: n*60 + mins})
+				i += 3
+			} else {
+				tokens = append(tokens, ohToken{kind: ohNumber, text: string(runes[start:i]), num: n})
+			}
+
+		case unicode.IsLetter(r):
+			start := i
+			for i < len(runes) && unicode.IsLetter(runes[i]) {
+				i++
+			}
+			tokens = append(tokens, ohToken{kind: ohWord, text: strings.ToLower(string(runes[start:i]))})
+
+		case r == '|' && i+1 < len(runes) && runes[i+1] == '|':
+			tokens = append(tokens, ohToken{kind: ohPunct, text: "||"})
+			i += 2
+
+		case strings.ContainsRune(";,-+[]:/()", r):
+			tokens = append(tokens, ohToken{kind: ohPunct, text: string(r)})
+			i++
+
+		default:
+			return nil, fmt.Errorf("carácter no válido en opening_hours: %q", r)
+		}
+	}
+
+	return tokens, nil
+}
+
+// ohParser recorre los tokens de una regla
+type ohParser struct {
+	tokens []ohToken
+	pos    int
+}
+
+func (p *ohParser) peek() (ohToken, bool) {
+	if p.pos >= len(p.tokens) {
+		return ohToken{}, false
+	}
+	return p.tokens[p.pos], true
+}
+
+func (p *ohParser) peekAt(offset int) (ohToken, bool) {
+	if p.pos+offset >= len(p.tokens) {
+		return ohToken{}, false
+	}
+	return p.tokens[p.pos+offset], true
+}
+
+func (p *ohParser) isPunct(text string) bool {
+	t, ok := p.peek()
+	return ok && t.kind == ohPunct && t.text == text
+}
+
+func (p *ohParser) isWord(pred func(string) bool) bool {
+	t, ok := p.peek()
+	return ok && t.kind == ohWord && pred(t.text)
+}
+
+func parseOHRule(tokens []ohToken) (ohRule, error) {
+	p := &ohParser{tokens: tokens}
+	rule := ohRule{state: ohOpen}
+
+	// 24/7
+	if len(tokens) >= 3 && tokens[0].kind == ohNumber && tokens[0].num == 24 &&
+		tokens[1].text == "/" && tokens[2].kind == ohNumber && tokens[2].num == 7 {
+		rule.allWeek = true
+		p.pos = 3
+	}
+
+	// Selectores amplios: años, meses/fechas, semanas
+	for {
+		if t, ok := p.peek(); ok && t.kind == ohNumber && t.num >= 1900 {
+			years, err := p.parseRanges(1900, 9999)
+			if err != nil {
+				return rule, err
+			}
+			rule.years = append(rule.years, years...)
+			continue
+		}
+		if p.isWord(func(w string) bool { _, ok := ohMonths[w]; return ok }) {
+			months, err := p.parseMonths()
+			if err != nil {
+				return rule, err
+			}
+			rule.months = append(rule.months, months...)
+			continue
+		}
+		if p.isWord(func(w string) bool { return w == "week" }) {
+			p.pos++
+			weeks, err := p.parseRanges(1, 53)
+			if err != nil {
+				return rule, err
+			}
+			rule.weeks = append(rule.weeks, weeks...)
+			continue
+		}
+		break
+	}
+
+	// Selectores pequeños: días de la semana y festivos
+	if err := p.parseWeekdays(&rule); err != nil {
+		return rule, err
+	}
+	if p.isPunct(":") {
+		p.pos++
+	}
+
+	// Horas
+	times, err := p.parseTimes()
+	if err != nil {
+		return rule, err
+	}
+	rule.times = times
+
+	// Modificador y comentario
+	explicitState := false
+	if t, ok := p.peek(); ok && t.kind == ohWord && isOHModifier(t.text) {
+		explicitState = true
+		switch t.text {
+		case "closed", "off":
+			rule.state = ohClosed
+		case "unknown":
+			rule.state = ohUnknown
+		}
+		p.pos++
+	}
+	if t, ok := p.peek(); ok && t.kind == ohComment {
+		rule.comment = t.text
+		p.pos++
+		// Un comentario sin horas ni estado ("por cita previa") no dice si está abierto
+		if len(rule.times) == 0 && !explicitState {
+			rule.state = ohUnknown
+		}
+	}
+
+	if t, ok := p.peek(); ok {
+		return rule, fmt.Errorf("opening_hours no reconocido cerca de %q", t.text)
+	}
+
+	return rule, nil
+}
+
+// parseRanges lee "n", "n-m" o listas separadas por comas
+func (p *ohParser) parseRanges(min, max int) ([]ohRange, error) {
+	var ranges []ohRange
+	for {
+		t, ok := p.peek()
+		if !ok || t.kind != ohNumber || t.num < min || t.num > max {
+			return nil, fmt.Errorf("rango no válido en opening_hours")
+		}
+		r := ohRange{from: t.num, to: t.num}
+		p.pos++
+		if p.isPunct("-") {
+			if n, ok := p.peekAt(1); ok && n.kind == ohNumber && n.num >= min && n.num <= max {
+				r.to = n.num
+				p.pos += 2
+			}
+		}
+		// Intervalo de repetición ("2026-2030/2"): se ignora
+		if p.isPunct("/") {
+			p.pos += 2
+		}
+		ranges = append(ranges, r)
+
+		if p.isPunct(",") {
+			if n, ok := p.peekAt(1); ok && n.kind == ohNumber && n.num >= min && n.num <= max {
+				p.pos++
+				continue
+			}
+		}
+		return ranges, nil
+	}
+}
+
+// parseMonths lee "Jan", "Jan-Mar", "Dec 24", "Dec 24-26", "Dec 24-Jan 02" y listas
+func (p *ohParser) parseMonths() ([]ohMonthRange, error) {
+	var ranges []ohMonthRange
+	for {
+		t, _ := p.peek()
+		month := ohMonths[t.text]
+		p.pos++
+
+		r := ohMonthRange{fromMonth: month, toMonth: month}
+		if n, ok := p.peek(); ok && n.kind == ohNumber && n.num >= 1 && n.num <= 31 {
+			r.fromDay, r.toDay = n.num, n.num
+			p.pos++
+		}
+
+		if p.isPunct("-") {
+			n, ok := p.peekAt(1)
+			switch {
+			case ok && n.kind == ohWord && ohMonths[n.text] != 0:
+				r.toMonth = ohMonths[n.text]
+				r.toDay = 0
+				p.pos += 2
+				if d, ok := p.peek(); ok && d.kind == ohNumber && d.num >= 1 && d.num <= 31 {
+					r.toDay = d.num
+					p.pos++
+				}
+			case ok && n.kind == ohNumber && r.fromDay > 0 && n.num >= 1 && n.num <= 31:
+				r.toDay = n.num
+				p.pos += 2
+			}
+		}
+		ranges = append(ranges, r)
+
+		if p.isPunct(",") {
+			if n, ok := p.peekAt(1); ok && n.kind == ohWord && ohMonths[n.text] != 0 {
+				p.pos++
+				continue
+			}
+		}
+		return ranges, nil
+	}
+}
+
+// parseWeekdays lee "Mo-Fr", "Sa,Su", "Mo[1]", "Su[-1]", "PH" y combinaciones
+func (p *ohParser) parseWeekdays(rule *ohRule) error {
+	for {
+		t, ok := p.peek()
+		if !ok || t.kind != ohWord {
+			return nil
+		}
+
+		switch {
+		case t.text == "ph":
+			rule.holiday = true
+			p.pos++
+			p.skipHolidayOffset()
+		case t.text == "sh":
+			rule.school = true
+			p.pos++
+		default:
+			from, isDay := ohWeekdays[t.text]
+			if !isDay {
+				return nil
+			}
+			p.pos++
+			wd := ohWeekday{from: from, to: from}
+			if p.isPunct("-") {
+				if n, ok := p.peekAt(1); ok && n.kind == ohWord {
+					if to, ok := ohWeekdays[n.text]; ok {
+						wd.to = to
+						p.pos += 2
+					}
+				}
+			}
+			if p.isPunct("[") {
+				nth, err := p.parseNth()
+				if err != nil {
+					return err
+				}
+				wd.nth = nth
+			}
+			rule.weekdays = append(rule.weekdays, wd)
+		}
+
+		if !p.isPunct(",") {
+			return nil
+		}
+		n, ok := p.peekAt(1)
+		if !ok || n.kind != ohWord {
+			return nil
+		}
+		if _, isDay := ohWeekdays[n.text]; !isDay && n.text != "ph" && n.text != "sh" {
+			return nil
+		}
+		p.pos++
+	}
+}
+
+// skipHolidayOffset ignora desplazamientos como "PH +1 day"
+func (p *ohParser) skipHolidayOffset() {
+	if (p.isPunct("+") || p.isPunct("-")) && p.pos+2 < len(p.tokens) &&
+		p.tokens[p.pos+1].kind == ohNumber && p.tokens[p.pos+2].kind == ohWord &&
+		strings.HasPrefix(p.tokens[p.pos+2].text, "day") {
+		p.pos += 3
+	}
+}
+
+// parseNth lee "[1]", "[1,3]", "[2-4]" o "[-1]"
+func (p *ohParser) parseNth() ([]ohRange, error) {
+	p.pos++ // [
+	var nth []ohRange
+	for {
+		sign := 1
+		if p.isPunct("-") {
+			sign = -1
+			p.pos++
+		}
+		t, ok := p.peek()
+		if !ok || t.kind != ohNumber || t.num < 1 || t.num > 5 {
+			return nil, fmt.Errorf("semana del mes no válida en opening_hours")
+		}
+		r := ohRange{from: sign * t.num, to: sign * t.num}
+		p.pos++
+		if sign > 0 && p.isPunct("-") {
+			if n, ok := p.peekAt(1); ok && n.kind == ohNumber && n.num >= 1 && n.num <= 5 {
+				r.to = n.num
+				p.pos += 2
+			}
+		}
+		nth = append(nth, r)
+
+		switch {
+		case p.isPunct(","):
+			p.pos++
+		case p.isPunct("]"):
+			p.pos++
+			return nth, nil
+		default:
+			return nil, fmt.Errorf("falta ']' en opening_hours")
+		}
+	}
+}
+
+// parseTimes lee "09:00-14:00,17:00-20:00", "18:00+" o "sunrise-sunset"
+func (p *ohParser) parseTimes() ([]Interval, error) {
+	var times []Interval
+	for {
+		open, ok := p.parseTimePoint()
+		if !ok {
+			return times, nil
+		}
+
+		iv := Interval{Open: open}
+		switch {
+		case p.isPunct("-"):
+			p.pos++
+			close, ok := p.parseTimePoint()
+			if !ok {
+				return nil, fmt.Errorf("falta la hora de cierre en opening_hours")
+			}
+			iv.Close = close
+			if p.isPunct("+") {
+				p.pos++
+			}
+			// Intervalos de repetición ("10:00-16:00/90"): se ignoran
+			if p.isPunct("/") {
+				p.pos += 2
+			}
+		case p.isPunct("+"):
+			// Hora de cierre abierta: se asume hasta medianoche
+			p.pos++
+			iv.Close = MinutesPerDay
+		default:
+			return nil, fmt.Errorf("hora sin rango en opening_hours")
+		}
+
+		if iv.Close <= iv.Open {
+			iv.Close += MinutesPerDay
+		}
+		times = append(times, iv)
+
+		if !p.isPunct(",") {
+			return times, nil
+		}
+		if n, ok := p.peekAt(1); !ok || (n.kind != ohTime && !(n.kind == ohWord && isOHVariableTime(n.text)) && !(n.kind == ohPunct && n.text == "(")) {
+			return times, nil
+		}
+		p.pos++
+	}
+}
+
+// parseTimePoint lee una hora fija o variable ("sunset", "(sunset-01:00)")
+func (p *ohParser) parseTimePoint() (int, bool) {
+	t, ok := p.peek()
+	if !ok {
+		return 0, false
+	}
+
+	switch {
+	case t.kind == ohTime:
+		p.pos++
+		return t.num, true
+	case t.kind == ohWord && isOHVariableTime(t.text):
+		p.pos++
+		return variableTime(t.text), true
+	case t.kind == ohPunct && t.text == "(":
+		// (sunset-01:00) o (sunrise+00:30)
+		if p.pos+4 < len(p.tokens) && p.tokens[p.pos+1].kind == ohWord &&
+			p.tokens[p.pos+3].kind == ohTime && p.tokens[p.pos+4].text == ")" {
+			base := variableTime(p.tokens[p.pos+1].text)
+			offset := p.tokens[p.pos+3].num
+			if p.tokens[p.pos+2].text == "-" {
+				offset = -offset
+			}
+			p.pos += 5
+			return base + offset, true
+		}
+	}
+
+	return 0, false
+}
+
+func variableTime(word string) int {
+	switch word {
+	case "dawn":
+		return approxDawn
+	case "sunrise":
+		return approxSunrise
+	case "sunset":
+		return approxSunset
+	default:
+		return approxDusk
+	}
+}
+
+// matches indica si los selectores de la regla incluyen la fecha
+func (r ohRule) matches(date time.Time, isHoliday bool) bool {
+	if r.allWeek {
+		return true
+	}
+
+	if len(r.years) > 0 && !inRanges(r.years, date.Year()) {
+		return false
+	}
+	if len(r.months) > 0 && !r.matchesMonths(date) {
+		return false
+	}
+	if len(r.weeks) > 0 {
+		_, week := date.ISOWeek()
+		if !inRanges(r.weeks, week) {
+			return false
+		}
+	}
+
+	// Sin selector de días, la regla aplica a todos
+	if len(r.weekdays) == 0 && !r.holiday && !r.school {
+		return true
+	}
+	if r.holiday && isHoliday {
+		return true
+	}
+	for _, wd := range r.weekdays {
+		if wd.matches(date) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r ohRule) matchesMonths(date time.Time) bool {
+	ord := int(date.Month())*100 + date.Day()
+	for _, m := range r.months {
+		fromDay, toDay := m.fromDay, m.toDay
+		if fromDay == 0 {
+			fromDay = 1
+		}
+		if toDay == 0 {
+			toDay = 31
+		}
+		from := m.fromMonth*100 + fromDay
+		to := m.toMonth*100 + toDay
+		if from <= to && ord >= from && ord <= to {
+			return true
+		}
+		if from > to && (ord >= from || ord <= to) {
+			return true
+		}
+	}
+	return false
+}
+
+func (wd ohWeekday) matches(date time.Time) bool {
+	day := date.Weekday()
+	inRange := false
+	for d := wd.from; ; d = (d + 1) % 7 {
+		if d == day {
+			inRange = true
+			break
+		}
+		if d == wd.to {
+			break
+		}
+	}
+	if !inRange {
+		return false
+	}
+	if len(wd.nth) == 0 {
+		return true
+	}
+
+	nth := (date.Day()-1)/7 + 1
+	daysInMonth := time.Date(date.Year(), date.Month()+1, 0, 0, 0, 0, 0, date.Location()).Day()
+	fromEnd := -((daysInMonth-date.Day())/7 + 1)
+	for _, r := range wd.nth {
+		if (nth >= r.from && nth <= r.to) || (fromEnd >= r.from && fromEnd <= r.to) {
+			return true
+		}
+	}
+	return false
+}
+
+func inRanges(ranges []ohRange, n int) bool {
+	for _, r := range ranges {
+		if n >= r.from && n <= r.to {
+			return true
+		}
+	}
+	return false
+}
+
+// DayAt evalúa las reglas para una fecha concreta.
+// Los días que no cubre ninguna regla están cerrados.
+func (oh *OpeningHours) DayAt(date time.Time, isHoliday bool) Day {
+	day := Day{Known: true, Closed: true}
+	matched := false
+
+	for _, rule := range oh.rules {
+		if rule.kind == ohFallback && matched {
+			continue
+		}
+		if !rule.matches(date, isHoliday) {
+			continue
+		}
+
+		// Una regla normal sustituye lo que dijeran las anteriores para ese día,
+		// salvo si solo cierra unos tramos ("We 12:00-14:00 off")
+		closesTimes := rule.state == ohClosed && len(rule.times) > 0
+		if rule.kind != ohAdditional && !closesTimes {
+			day = Day{}
+		}
+		matched = true
+
+		switch rule.state {
+		case ohUnknown:
+			day = Day{}
+		case ohClosed:
+			if len(rule.times) == 0 {
+				day = Day{Known: true, Closed: true}
+			} else {
+				day = subtractIntervals(day, rule.times)
+			}
+		default:
+			day.Known = true
+			day.Closed = false
+			if len(rule.times) == 0 {
+				day.AllDay = true
+				day.Intervals = nil
+			} else if !day.AllDay {
+				day.Intervals = append(day.Intervals, rule.times...)
+			}
+		}
+	}
+
+	return day
+}
+
+// Week evalúa las reglas para los 7 días que empiezan en ref. isHoliday,
+// si no es nil, indica qué fechas son festivos (selector PH).
+func (oh *OpeningHours) Week(ref time.Time, isHoliday func(time.Time) bool) *Week {
+	week := &Week{}
+	for offset := 0; offset < 7; offset++ {
+		date := time.Date(ref.Year(), ref.Month(), ref.Day()+offset, 0, 0, 0, 0, ref.Location())
+		holiday := isHoliday != nil && isHoliday(date)
+		week.Days[date.Weekday()] = oh.DayAt(date, holiday)
+	}
+	return week
+}
+
+// subtractIntervals quita tramos cerrados ("Mo 12:00-13:00 off") del horario de un día
+func subtractIntervals(day Day, closed []Interval) Day {
+	open := day.Intervals
+	if day.AllDay {
+		open = []Interval{{Open: 0, Close: MinutesPerDay}}
+	}
+
+	for _, c := range closed {
+		var next []Interval
+		for _, o := range open {
+			if c.Close <= o.Open || c.Open >= o.Close {
+				next = append(next, o)
+				continue
+			}
+			if c.Open > o.Open {
+				next = append(next, Interval{Open: o.Open, Close: c.Open})
+			}
+			if c.Close < o.Close {
+				next = append(next, Interval{Open: c.Close, Close: o.Close})
+			}
+		}
+		open = next
+	}
+
+	result := Day{Known: true, Intervals: open}
+	if len(open) == 0 {
+		result.Closed = true
+	}
+	return result
+}
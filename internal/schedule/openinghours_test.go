@@ -0,0 +1,117 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+)
+
+func TestOpeningHoursWeek(t *testing.T) {
+	// Semana del lunes 12 de octubre de 2026, festivo nacional en España
+	ref := time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC)
+	holiday := func(date time.Time) bool {
+		return date.Month() == time.October && date.Day() == 12
+	}
+
+	tests := []struct {
+		spec string
+		want string
+	}{
+		{
+			spec: "Mo-Fr 09:00-14:00,17:00-20:00; Sa 10:00-14:00",
+			want: "lun=09:00-14:00, 17:00-20:00 mar=09:00-14:00, 17:00-20:00 mié=09:00-14:00, 17:00-20:00 jue=09:00-14:00, 17:00-20:00 vie=09:00-14:00, 17:00-20:00 sáb=10:00 - 14:00 dom=cerrado",
+		},
+		{
+			spec: "24/7",
+			want: "lun=Abierto 24 horas mar=Abierto 24 horas mié=Abierto 24 horas jue=Abierto 24 horas vie=Abierto 24 horas sáb=Abierto 24 horas dom=Abierto 24 horas",
+		},
+		{
+			spec: "Fr-Sa 22:00-03:00",
+			want: "lun=cerrado mar=cerrado mié=cerrado jue=cerrado vie=22:00 - 03:00 sáb=22:00 - 03:00 dom=cerrado",
+		},
+		{
+			spec: "Mo-Sa 10:00-20:00; PH off",
+			want: "lun=cerrado mar=10:00 - 20:00 mié=10:00 - 20:00 jue=10:00 - 20:00 vie=10:00 - 20:00 sáb=10:00 - 20:00 dom=cerrado",
+		},
+		{
+			spec: "Mo-Su 08:00-22:00; We off",
+			want: "lun=08:00 - 22:00 mar=08:00 - 22:00 mié=cerrado jue=08:00 - 22:00 vie=08:00 - 22:00 sáb=08:00 - 22:00 dom=08:00 - 22:00",
+		},
+		{
+			spec: "Mo,We 10:00-12:00",
+			want: "lun=10:00 - 12:00 mar=cerrado mié=10:00 - 12:00 jue=cerrado vie=cerrado sáb=cerrado dom=cerrado",
+		},
+		{
+			spec: "Mo-Fr 09:00-20:00; Mo-Fr 14:00-16:00 off",
+			want: "lun=09:00-14:00, 16:00-20:00 mar=09:00-14:00, 16:00-20:00 mié=09:00-14:00, 16:00-20:00 jue=09:00-14:00, 16:00-20:00 vie=09:00-14:00, 16:00-20:00 sáb=cerrado dom=cerrado",
+		},
+		{
+			spec: "Mo-Fr 08:00-15:00, Sa 09:00-13:00",
+			want: "lun=08:00 - 15:00 mar=08:00 - 15:00 mié=08:00 - 15:00 jue=08:00 - 15:00 vie=08:00 - 15:00 sáb=09:00 - 13:00 dom=cerrado",
+		},
+		{
+			spec: "Oct Mo-Fr 10:00-18:00; Nov-Mar off",
+			want: "lun=10:00 - 18:00 mar=10:00 - 18:00 mié=10:00 - 18:00 jue=10:00 - 18:00 vie=10:00 - 18:00 sáb=cerrado dom=cerrado",
+		},
+		{
+			spec: "Jun-Sep Mo-Su 10:00-22:00",
+			want: "lun=cerrado mar=cerrado mié=cerrado jue=cerrado vie=cerrado sáb=cerrado dom=cerrado",
+		},
+		{
+			spec: "Mo-Fr 09:00-18:00; Oct 16 off",
+			want: "lun=09:00 - 18:00 mar=09:00 - 18:00 mié=09:00 - 18:00 jue=09:00 - 18:00 vie=cerrado sáb=cerrado dom=cerrado",
+		},
+		{
+			spec: "Sa[3] 10:00-14:00",
+			want: "lun=cerrado mar=cerrado mié=cerrado jue=cerrado vie=cerrado sáb=10:00 - 14:00 dom=cerrado",
+		},
+		{
+			spec: "Su[1] 10:00-14:00",
+			want: "lun=cerrado mar=cerrado mié=cerrado jue=cerrado vie=cerrado sáb=cerrado dom=cerrado",
+		},
+		{
+			spec: "sunrise-sunset",
+			want: "lun=07:00 - 19:00 mar=07:00 - 19:00 mié=07:00 - 19:00 jue=07:00 - 19:00 vie=07:00 - 19:00 sáb=07:00 - 19:00 dom=07:00 - 19:00",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.spec, func(t *testing.T) {
+			oh, err := ParseOpeningHours(tc.spec)
+			if err != nil {
+				t.Fatalf("ParseOpeningHours(%q): %v", tc.spec, err)
+			}
+			if got := weekString(oh.Week(ref, holiday)); got != tc.want {
+				t.Errorf("Week(%q)\n got: %s\nwant: %s", tc.spec, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestOpeningHoursSpans(t *testing.T) {
+	oh, err := ParseOpeningHours("Fr-Sa 22:00-03:00")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ref := time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC)
+	week := oh.Week(ref, nil)
+
+	// Domingo a la 01:00: sigue el turno del sábado
+	c := week.CountdownAt(time.Date(2026, 10, 18, 1, 0, 0, 0, time.UTC))
+	if !c.Open || c.ClosesIn != 2*time.Hour {
+		t.Errorf("domingo 01:00: %+v, want open, closes in 2h", c)
+	}
+	// Jueves a la 01:00: el miércoles no abre
+	c = week.CountdownAt(time.Date(2026, 10, 15, 1, 0, 0, 0, time.UTC))
+	if c.Open || c.OpensIn != 45*time.Hour {
+		t.Errorf("jueves 01:00: %+v, want closed, opens in 45h", c)
+	}
+}
+
+func TestParseOpeningHoursInvalid(t *testing.T) {
+	for _, spec := range []string{"", "Mo-Fr 09:75-14:00", "Mo-Fr 09:00-", "abierto siempre", "Mo-Xx 09:00-14:00"} {
+		t.Run(spec, func(t *testing.T) {
+			if _, err := ParseOpeningHours(spec); err == nil {
+				t.Errorf("ParseOpeningHours(%q) sin error", spec)
+			}
+		})
+	}
+}
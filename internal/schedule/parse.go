@@ -0,0 +1,548 @@
+package schedule
+
+import (
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/686f6c61/pingbar/internal/locale"
+)
+
+type tokenKind int
+
+const (
+	tokWord tokenKind = iota
+	tokDay
+	tokDaySet
+	tokTime
+	tokRange
+	tokAnd
+	tokSep
+	tokClosed
+	tokAllDay
+)
+
+type token struct {
+	kind     tokenKind
+	text     string
+	days     []time.Weekday // tokDay y tokDaySet
+	weak     bool           // Abreviatura de día ("mar", "dom") que puede ser otra palabra
+	mins     int            // tokTime
+	explicit bool           // tokTime con minutos o sufijo "h"
+}
+
+// vocabulary son las palabras de un idioma que reconoce el parser
+type vocabulary struct {
+	days         map[string]time.Weekday
+	weakDays     map[string]time.Weekday // Abreviatura de día ("mar", "dom") que puede ser otra palabra
+	daySets      map[string][]time.Weekday
+	rangeWords   map[string]bool
+	andWords     map[string]bool
+	closedWords  map[string]bool
+	hourSuffixes map[string]bool // Marcan que el número anterior es una hora ("10h", "22 hrs")
+	fillerWords  map[string]bool // No cortan la secuencia día-hora ("de lunes a viernes de 9 a 14")
+}
+
+var vocabularies = buildVocabularies()
+
+func buildVocabularies() map[string]*vocabulary {
+	vocabs := make(map[string]*vocabulary)
+	for _, lang := range locale.Languages() {
+		kw := locale.KeywordsFor(lang)
+		vocabs[lang] = &vocabulary{
+			days:         kw.Days,
+			weakDays:     kw.WeakDays,
+			daySets:      kw.DaySets,
+			rangeWords:   wordSet(kw.Range),
+			andWords:     wordSet(kw.And),
+			closedWords:  wordSet(kw.Closed),
+			hourSuffixes: wordSet(kw.HourSuffixes),
+			fillerWords:  wordSet(kw.Fillers),
+		}
+	}
+	return vocabs
+}
+
+func wordSet(words []string) map[string]bool {
+	set := make(map[string]bool, len(words))
+	for _, w := range words {
+		set[w] = true
+	}
+	return set
+}
+
+// vocabularyFor devuelve el vocabulario de un idioma (español si no se conoce)
+func vocabularyFor(lang string) *vocabulary {
+	if v, ok := vocabularies[strings.ToLower(lang)]; ok {
+		return v
+	}
+	return vocabularies["es"]
+}
+
+// Parse extrae un horario semanal de un texto libre en español (por ejemplo,
+// un snippet de Google). Devuelve nil si el texto no contiene ningún horario.
+func Parse(text string) *Week {
+	return ParseLang(text, "es")
+}
+
+// ParseLang extrae un horario semanal de un texto libre en el idioma indicado
+// ("es", "en", "fr"...). Las horas con AM/PM se reconocen en cualquier idioma.
+func ParseLang(text, lang string) *Week {
+	vocab := vocabularyFor(lang)
+	tokens := resolveWeakDays(tokenize(strings.ToLower(text), vocab), vocab)
+
+	p := &parser{vocab: vocab}
+	for _, tok := range tokens {
+		p.feed(tok)
+	}
+	p.flushPending()
+	if p.closedNext {
+		p.flushClosed()
+	}
+
+	return p.result()
+}
+
+// tokenize divide el texto en palabras, horas y separadores
+func tokenize(text string, vocab *vocabulary) []token {
+	runes := []rune(text)
+	tokens := make([]token, 0, len(runes)/4)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+
+		switch {
+		case unicode.IsLetter(r):
+			start := i
+			for i < len(runes) && unicode.IsLetter(runes[i]) {
+				i++
+			}
+			tokens = append(tokens, vocab.classifyWord(string(runes[start:i])))
+
+		case unicode.IsDigit(r):
+			tok, next := readTime(runes, i, vocab)
+			tokens = append(tokens, tok)
+			i = next
+
+		case r == '-' || r == '–' || r == '—':
+			tokens = append(tokens, token{kind: tokRange, text: string(r)})
+			i++
+
+		case r == ',':
+			tokens = append(tokens, token{kind: tokAnd, text: ","})
+			i++
+
+		case r == ';' || r == '|' || r == '\n' || r == '·' || r == '•':
+			tokens = append(tokens, token{kind: tokSep, text: string(r)})
+			i++
+
+		default:
+			i++
+		}
+	}
+
+	return tokens
+}
+
+// readTime lee una hora ("9", "09:30", "9.30", "10h", "9h30", "5pm", "24/7")
+// a partir de runes[i]
+func readTime(runes []rune, i int, vocab *vocabulary) (token, int) {
+	start := i
+	hours := 0
+	for i < len(runes) && unicode.IsDigit(runes[i]) {
+		hours = hours*10 + int(runes[i]-'0')
+		i++
+	}
+	tok := token{kind: tokWord, text: string(runes[start:i])}
+
+	// "24/7"
+	if hours == 24 && i+1 < len(runes) && runes[i] == '/' && runes[i+1] == '7' {
+		return token{kind: tokAllDay, text: "24/7"}, i + 2
+	}
+
+	if i-start > 2 || hours > 24 {
+		return tok, i
+	}
+
+	mins := 0
+	explicit := false
+	if i+2 < len(runes) && (runes[i] == ':' || runes[i] == '.') &&
+		unicode.IsDigit(runes[i+1]) && unicode.IsDigit(runes[i+2]) &&
+		(i+3 >= len(runes) || !unicode.IsDigit(runes[i+3])) {
+		mins = int(runes[i+1]-'0')*10 + int(runes[i+2]-'0')
+		explicit = true
+		i += 3
+	}
+
+	if mins >= 60 || (hours == 24 && mins != 0) {
+		return tok, i
+	}
+
+	// Sufijo de hora opcional: "10h", "14 h", "24 horas", "9h30"
+	hasMins := explicit
+	end := i
+	j := i
+	for j < len(runes) && runes[j] == ' ' {
+		j++
+	}
+	k := j
+	for k < len(runes) && unicode.IsLetter(runes[k]) {
+		k++
+	}
+	if suffix := string(runes[j:k]); k > j && vocab.hourSuffixes[suffix] {
+		explicit = true
+		i = k
+		if suffix == "h" && j == end && !hasMins && twoDigits(runes, i) {
+			mins = int(runes[i]-'0')*10 + int(runes[i+1]-'0')
+			if mins >= 60 || hours == 24 {
+				return tok, i
+			}
+			i += 2
+		}
+	} else if half, next, ok := readMeridiem(runes, j); ok && hours >= 1 && hours <= 12 {
+		// Reloj de 12 horas: "9am", "5:30 pm", "12 p.m."
+		hours %= 12
+		if half == 'p' {
+			hours += 12
+		}
+		explicit = true
+		i = next
+	}
+
+	return token{
+		kind:     tokTime,
+		text:     string(runes[start:i]),
+		mins:     hours*60 + mins,
+		explicit: explicit,
+	}, i
+}
+
+// twoDigits indica si en runes[i] hay exactamente dos dígitos ("9h30")
+func twoDigits(runes []rune, i int) bool {
+	return i+1 < len(runes) && unicode.IsDigit(runes[i]) && unicode.IsDigit(runes[i+1]) &&
+		(i+2 >= len(runes) || !unicode.IsDigit(runes[i+2]))
+}
+
+// readMeridiem lee "am", "pm", "a.m." o "p.m." a partir de runes[j] y
+// devuelve 'a' o 'p' y la posición siguiente
+func readMeridiem(runes []rune, j int) (rune, int, bool) {
+	if j >= len(runes) || (runes[j] != 'a' && runes[j] != 'p') {
+		return 0, j, false
+	}
+	k := j + 1
+	if k < len(runes) && runes[k] == '.' {
+		k++
+	}
+	if k >= len(runes) || runes[k] != 'm' {
+		return 0, j, false
+	}
+	k++
+	if k < len(runes) && runes[k] == '.' {
+		k++
+	}
+	if k < len(runes) && unicode.IsLetter(runes[k]) {
+		return 0, j, false
+	}
+	return runes[j], k, true
+}
+
+func (v *vocabulary) classifyWord(word string) token {
+	tok := token{kind: tokWord, text: word}
+
+	if d, ok := v.days[word]; ok {
+		tok.kind = tokDay
+		tok.days = []time.Weekday{d}
+		return tok
+	}
+	if d, ok := v.weakDays[word]; ok {
+		tok.kind = tokDay
+		tok.days = []time.Weekday{d}
+		tok.weak = true
+		return tok
+	}
+	if days, ok := v.daySets[word]; ok {
+		tok.kind = tokDaySet
+		tok.days = days
+		return tok
+	}
+
+	switch {
+	case v.rangeWords[word]:
+		tok.kind = tokRange
+	case v.andWords[word]:
+		tok.kind = tokAnd
+	case v.closedWords[word]:
+		tok.kind = tokClosed
+	}
+	return tok
+}
+
+// resolveWeakDays descarta abreviaturas de día que no parecen un día ("Bar
+// del Mar" no es un martes). Una abreviatura es un día si está unida a otro
+// día ("lun-vie"), si le sigue una hora ("sáb 10:00-14:00") o si abre un
+// tramo nuevo tras otro horario y le siguen horas o "cerrado" ("lun-vie 9 a
+// 14, sáb de 10 a 14, dom cerrado").
+func resolveWeakDays(tokens []token, vocab *vocabulary) []token {
+	kindAt := func(i int) (tokenKind, bool) {
+		if i < 0 || i >= len(tokens) {
+			return 0, false
+		}
+		return tokens[i].kind, true
+	}
+	isDay := func(i int) bool {
+		k, ok := kindAt(i)
+		return ok && k == tokDay
+	}
+	isLink := func(i int) bool {
+		k, ok := kindAt(i)
+		return ok && (k == tokRange || k == tokAnd)
+	}
+	isHours := func(i int) bool {
+		k, ok := kindAt(i)
+		return ok && (k == tokTime || k == tokAllDay)
+	}
+
+	// clauseStart indica si tokens[i] empieza el texto o un tramo nuevo tras
+	// un horario ("9:00-14:00, sáb")
+	clauseStart := func(i int) bool {
+		k, ok := kindAt(i - 1)
+		if !ok {
+			return true
+		}
+		if k == tokSep {
+			return true
+		}
+		prev, _ := kindAt(i - 2)
+		return k == tokAnd && (prev == tokTime || prev == tokAllDay || prev == tokClosed)
+	}
+	// hoursAfter indica si tras tokens[i] vienen horas o "cerrado", saltando
+	// palabras de relleno ("sáb de 10 a 14")
+	hoursAfter := func(i int) bool {
+		for j := i + 1; j < len(tokens); j++ {
+			switch {
+			case isHours(j) || tokens[j].kind == tokClosed:
+				return true
+			case tokens[j].kind == tokWord && vocab.fillerWords[tokens[j].text]:
+				continue
+			}
+			return false
+		}
+		return false
+	}
+
+	for i, tok := range tokens {
+		if tok.kind != tokDay || !tok.weak {
+			continue
+		}
+		if (isLink(i-1) && isDay(i-2)) || (isLink(i+1) && isDay(i+2)) {
+			continue
+		}
+		if isHours(i+1) || (clauseStart(i) && hoursAfter(i)) {
+			continue
+		}
+		tokens[i].kind = tokWord
+	}
+
+	return tokens
+}
+
+// parser recorre los tokens asignando tramos horarios a los días que los preceden
+type parser struct {
+	week    Week
+	generic Day // Tramos sin día asociado ("abierto de 10:00 a 22:00")
+
+	cur        []time.Weekday // Días a los que se aplican los tramos siguientes
+	assigned   bool           // Ya se asignó algún horario a cur
+	inDayRange bool           // Visto "lunes a", se espera el día final
+	last       tokenKind
+
+	pending   *token // Hora de apertura a la espera de la de cierre
+	rangeOpen bool   // Visto "9:00 a", se espera la hora de cierre
+
+	closedNext bool // Visto "cerrado" sin días: se aplica a los días siguientes ("cerrado los domingos")
+
+	vocab *vocabulary
+}
+
+func (p *parser) feed(tok token) {
+	if tok.kind == tokWord && p.vocab.fillerWords[tok.text] {
+		return
+	}
+	if p.closedNext && tok.kind != tokDay && tok.kind != tokDaySet && tok.kind != tokRange && tok.kind != tokAnd {
+		p.flushClosed()
+	}
+
+	switch tok.kind {
+	case tokDay, tokDaySet:
+		p.flushPending()
+		if tok.kind == tokDay && p.inDayRange && len(p.cur) > 0 {
+			p.cur = expandRange(p.cur[len(p.cur)-1], tok.days[0], p.cur)
+		} else if p.assigned || len(p.cur) == 0 || p.last == tokTime {
+			p.cur = append([]time.Weekday{}, tok.days...)
+			p.assigned = false
+		} else {
+			p.cur = append(p.cur, tok.days...)
+		}
+		p.inDayRange = false
+
+	case tokTime:
+		p.inDayRange = false
+		if p.rangeOpen && p.pending != nil {
+			p.addInterval(*p.pending, tok)
+			p.pending = nil
+			p.rangeOpen = false
+		} else {
+			p.flushPending()
+			t := tok
+			p.pending = &t
+		}
+
+	case tokRange:
+		switch p.last {
+		case tokDay:
+			p.inDayRange = true
+		case tokTime:
+			p.rangeOpen = p.pending != nil
+		}
+
+	case tokAnd:
+		// Conecta días o tramos; no cambia el estado
+
+	case tokClosed:
+		p.flushPending()
+		if len(p.cur) > 0 && !p.assigned {
+			p.applyClosed()
+		} else {
+			// Los días vienen después; si no llega ninguno, cierra los no especificados
+			p.cur = nil
+			p.assigned = false
+			p.closedNext = true
+		}
+
+	case tokAllDay:
+		p.flushPending()
+		p.applyAllDay()
+
+	case tokSep:
+		p.flushPending()
+		if p.assigned {
+			p.cur = nil
+			p.assigned = false
+		}
+		p.inDayRange = false
+
+	default:
+		p.flushPending()
+		p.inDayRange = false
+	}
+
+	p.last = tok.kind
+}
+
+// flushClosed aplica un "cerrado" pendiente a los días leídos desde entonces
+func (p *parser) flushClosed() {
+	p.closedNext = false
+	p.applyClosed()
+}
+
+func (p *parser) applyClosed() {
+	p.apply(func(d *Day) {
+		d.Known = true
+		d.Closed = true
+		d.AllDay = false
+		d.Intervals = nil
+	})
+}
+
+// flushPending descarta una hora suelta; "24 horas" o "24h" se interpreta como abierto todo el día
+func (p *parser) flushPending() {
+	if p.pending != nil && p.pending.mins == MinutesPerDay && p.pending.explicit {
+		p.applyAllDay()
+	}
+	p.pending = nil
+	p.rangeOpen = false
+}
+
+func (p *parser) applyAllDay() {
+	p.apply(func(d *Day) {
+		d.Known = true
+		d.AllDay = true
+		d.Closed = false
+		d.Intervals = nil
+	})
+}
+
+func (p *parser) addInterval(open, close token) {
+	// Al menos un extremo debe ser claramente una hora ("9 a 14" podría ser otra cosa)
+	if !open.explicit && !close.explicit {
+		return
+	}
+
+	iv := Interval{Open: open.mins, Close: close.mins}
+	if iv.Open == MinutesPerDay {
+		iv.Open = 0
+	}
+	if iv.Close <= iv.Open {
+		iv.Close += MinutesPerDay
+	}
+	if iv.Close-iv.Open >= MinutesPerDay {
+		return
+	}
+
+	p.apply(func(d *Day) {
+		d.Known = true
+		d.Closed = false
+		d.AllDay = false
+		for _, existing := range d.Intervals {
+			if existing == iv {
+				return
+			}
+		}
+		d.Intervals = append(d.Intervals, iv)
+	})
+}
+
+func (p *parser) apply(fn func(d *Day)) {
+	if len(p.cur) == 0 {
+		fn(&p.generic)
+	} else {
+		for _, wd := range p.cur {
+			fn(&p.week.Days[wd])
+		}
+	}
+	p.assigned = true
+}
+
+func (p *parser) result() *Week {
+	if !p.generic.Known && p.week.KnownDays() == 0 {
+		return nil
+	}
+
+	// Los tramos sin día se aplican a los días no especificados
+	if p.generic.Known {
+		for i := range p.week.Days {
+			if !p.week.Days[i].Known {
+				day := p.generic
+				day.Intervals = append([]Interval(nil), p.generic.Intervals...)
+				day.Assumed = true
+				p.week.Days[i] = day
+			}
+		}
+	}
+
+	return &p.week
+}
+
+// expandRange añade a days los días entre from y to (ambos incluidos)
+func expandRange(from, to time.Weekday, days []time.Weekday) []time.Weekday {
+	if from == to {
+		return days
+	}
+	for d := (from + 1) % 7; ; d = (d + 1) % 7 {
+		days = append(days, d)
+		if d == to {
+			break
+		}
+	}
+	return days
+}
@@ -0,0 +1,214 @@
+package schedule
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/686f6c61/pingbar/internal/locale"
+)
+
+// weekString resume un horario como "lun=09:00 - 14:00 mar=cerrado ...",
+// con "?" en los días sin información y "~" en los deducidos
+func weekString(w *Week) string {
+	if w == nil {
+		return "<nil>"
+	}
+	names := map[time.Weekday]string{
+		time.Monday: "lun", time.Tuesday: "mar", time.Wednesday: "mié",
+		time.Thursday: "jue", time.Friday: "vie", time.Saturday: "sáb", time.Sunday: "dom",
+	}
+	parts := make([]string, 0, 7)
+	for _, wd := range WeekOrder {
+		d := w.Day(wd)
+		text := d.Hours()
+		switch {
+		case !d.Known:
+			text = "?"
+		case d.AllDay:
+			text = "Abierto 24 horas"
+		case d.Closed:
+			text = "cerrado"
+		}
+		if d.Known && d.Assumed {
+			text = "~" + text
+		}
+		parts = append(parts, names[wd]+"="+text)
+	}
+	return strings.Join(parts, " ")
+}
+
+type parseCase struct {
+	name string
+	text string
+	want string
+}
+
+func runParseCases(t *testing.T, lang string, cases []parseCase) {
+	t.Helper()
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := weekString(ParseLang(tc.text, lang))
+			if got != tc.want {
+				t.Errorf("ParseLang(%q, %q)\n got: %s\nwant: %s", tc.text, lang, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestParseSpanish(t *testing.T) {
+	runParseCases(t, "es", []parseCase{
+		{
+			name: "abreviaturas con sábado tras coma",
+			text: "Lun-Vie 9:00-14:00, Sáb 10:00-13:00",
+			want: "lun=09:00 - 14:00 mar=09:00 - 14:00 mié=09:00 - 14:00 jue=09:00 - 14:00 vie=09:00 - 14:00 sáb=10:00 - 13:00 dom=?",
+		},
+		{
+			name: "abreviaturas con relleno y domingo cerrado",
+			text: "Lun a Vie de 9 a 14h, Sáb de 10 a 13h, Dom cerrado",
+			want: "lun=09:00 - 14:00 mar=09:00 - 14:00 mié=09:00 - 14:00 jue=09:00 - 14:00 vie=09:00 - 14:00 sáb=10:00 - 13:00 dom=cerrado",
+		},
+		{
+			name: "nombre con abreviatura y punto",
+			text: "Bar del Mar. Abierto 24 horas",
+			want: "lun=~Abierto 24 horas mar=~Abierto 24 horas mié=~Abierto 24 horas jue=~Abierto 24 horas vie=~Abierto 24 horas sáb=~Abierto 24 horas dom=~Abierto 24 horas",
+		},
+		{
+			name: "nombre con abreviatura sin punto",
+			text: "Bar del Mar abierto de 12:00 a 23:00",
+			want: "lun=~12:00 - 23:00 mar=~12:00 - 23:00 mié=~12:00 - 23:00 jue=~12:00 - 23:00 vie=~12:00 - 23:00 sáb=~12:00 - 23:00 dom=~12:00 - 23:00",
+		},
+		{
+			name: "nombre con abreviatura y cerrado",
+			text: "Bar del Mar cerrado los domingos",
+			want: "lun=? mar=? mié=? jue=? vie=? sáb=? dom=cerrado",
+		},
+		{
+			name: "días completos y jornada partida",
+			text: "Horario: lunes a viernes de 10:00 a 14:00 y de 17:00 a 20:30. Sábados de 10:00 a 14:00. Domingos cerrado.",
+			want: "lun=10:00-14:00, 17:00-20:30 mar=10:00-14:00, 17:00-20:30 mié=10:00-14:00, 17:00-20:30 jue=10:00-14:00, 17:00-20:30 vie=10:00-14:00, 17:00-20:30 sáb=10:00 - 14:00 dom=cerrado",
+		},
+		{
+			name: "cierre después de medianoche",
+			text: "Viernes y sábados de 22:00 a 03:00",
+			want: "lun=? mar=? mié=? jue=? vie=22:00 - 03:00 sáb=22:00 - 03:00 dom=?",
+		},
+		{
+			name: "abierto todos los días",
+			text: "Abierto todos los días de 8:00 a 22:00",
+			want: "lun=08:00 - 22:00 mar=08:00 - 22:00 mié=08:00 - 22:00 jue=08:00 - 22:00 vie=08:00 - 22:00 sáb=08:00 - 22:00 dom=08:00 - 22:00",
+		},
+		{
+			name: "sin horario",
+			text: "El mejor bar de tapas del barrio, con terraza",
+			want: "<nil>",
+		},
+	})
+}
+
+func TestParseEnglish(t *testing.T) {
+	runParseCases(t, "en", []parseCase{
+		{
+			name: "weak sunday after a range",
+			text: "Mon-Sat 9am-9pm, Sun 10am-6pm",
+			want: "lun=09:00 - 21:00 mar=09:00 - 21:00 mié=09:00 - 21:00 jue=09:00 - 21:00 vie=09:00 - 21:00 sáb=09:00 - 21:00 dom=10:00 - 18:00",
+		},
+		{
+			name: "full names and closed",
+			text: "Open Monday to Friday from 8:30 am to 5:30 pm. Saturday 9 am to 1 pm. Sunday closed.",
+			want: "lun=08:30 - 17:30 mar=08:30 - 17:30 mié=08:30 - 17:30 jue=08:30 - 17:30 vie=08:30 - 17:30 sáb=09:00 - 13:00 dom=cerrado",
+		},
+		{
+			name: "daily 24/7",
+			text: "Open daily 24/7",
+			want: "lun=Abierto 24 horas mar=Abierto 24 horas mié=Abierto 24 horas jue=Abierto 24 horas vie=Abierto 24 horas sáb=Abierto 24 horas dom=Abierto 24 horas",
+		},
+		{
+			name: "weak word in a name",
+			text: "Sun Valley Diner, open from 7am to 3pm",
+			want: "lun=~07:00 - 15:00 mar=~07:00 - 15:00 mié=~07:00 - 15:00 jue=~07:00 - 15:00 vie=~07:00 - 15:00 sáb=~07:00 - 15:00 dom=~07:00 - 15:00",
+		},
+	})
+}
+
+func TestParseFrench(t *testing.T) {
+	runParseCases(t, "fr", []parseCase{
+		{
+			name: "abréviations après une plage",
+			text: "lun-ven 9h-18h, sam 10h-13h",
+			want: "lun=09:00 - 18:00 mar=09:00 - 18:00 mié=09:00 - 18:00 jue=09:00 - 18:00 vie=09:00 - 18:00 sáb=10:00 - 13:00 dom=?",
+		},
+		{
+			name: "jours complets",
+			text: "Ouvert du lundi au samedi de 9h30 à 19h, fermé le dimanche",
+			want: "lun=09:30 - 19:00 mar=09:30 - 19:00 mié=09:30 - 19:00 jue=09:30 - 19:00 vie=09:30 - 19:00 sáb=09:30 - 19:00 dom=cerrado",
+		},
+	})
+}
+
+func TestParsePortuguese(t *testing.T) {
+	runParseCases(t, "pt", []parseCase{
+		{
+			name: "abreviaturas após um intervalo",
+			text: "seg-sex 9h-18h, sáb 9h-13h",
+			want: "lun=09:00 - 18:00 mar=09:00 - 18:00 mié=09:00 - 18:00 jue=09:00 - 18:00 vie=09:00 - 18:00 sáb=09:00 - 13:00 dom=?",
+		},
+		{
+			name: "dias completos",
+			text: "Aberto de segunda a sexta-feira das 8h às 20h. Domingo fechado",
+			want: "lun=08:00 - 20:00 mar=08:00 - 20:00 mié=08:00 - 20:00 jue=08:00 - 20:00 vie=08:00 - 20:00 sáb=? dom=cerrado",
+		},
+	})
+}
+
+func TestParseItalian(t *testing.T) {
+	runParseCases(t, "it", []parseCase{
+		{
+			name: "abbreviazioni dopo un intervallo",
+			text: "lun-ven 9:00-19:00, sab 9:00-13:00",
+			want: "lun=09:00 - 19:00 mar=09:00 - 19:00 mié=09:00 - 19:00 jue=09:00 - 19:00 vie=09:00 - 19:00 sáb=09:00 - 13:00 dom=?",
+		},
+		{
+			name: "giorni completi",
+			text: "Aperto dal lunedì al sabato dalle 8:00 alle 20:00, domenica chiuso",
+			want: "lun=08:00 - 20:00 mar=08:00 - 20:00 mié=08:00 - 20:00 jue=08:00 - 20:00 vie=08:00 - 20:00 sáb=08:00 - 20:00 dom=cerrado",
+		},
+	})
+}
+
+func TestParseGerman(t *testing.T) {
+	runParseCases(t, "de", []parseCase{
+		{
+			name: "Abkürzungen nach einem Zeitraum",
+			text: "Mo-Fr 9-18 Uhr, Sa 10-14 Uhr",
+			want: "lun=09:00 - 18:00 mar=09:00 - 18:00 mié=09:00 - 18:00 jue=09:00 - 18:00 vie=09:00 - 18:00 sáb=10:00 - 14:00 dom=?",
+		},
+		{
+			name: "volle Namen und Ruhetag",
+			text: "Öffnungszeiten: Montag bis Samstag von 11:00 bis 23:00 Uhr, Sonntag Ruhetag",
+			want: "lun=11:00 - 23:00 mar=11:00 - 23:00 mié=11:00 - 23:00 jue=11:00 - 23:00 vie=11:00 - 23:00 sáb=11:00 - 23:00 dom=cerrado",
+		},
+	})
+}
+
+func TestParseCatalan(t *testing.T) {
+	runParseCases(t, "ca", []parseCase{
+		{
+			name: "dies complets",
+			text: "Obert de dilluns a divendres de 9:00 a 14:00 i de 16:00 a 20:00, dissabte de 10:00 a 14:00, diumenge tancat",
+			want: "lun=09:00-14:00, 16:00-20:00 mar=09:00-14:00, 16:00-20:00 mié=09:00-14:00, 16:00-20:00 jue=09:00-14:00, 16:00-20:00 vie=09:00-14:00, 16:00-20:00 sáb=10:00 - 14:00 dom=cerrado",
+		},
+	})
+}
+
+func TestParseMeridiemInAnyLanguage(t *testing.T) {
+	for _, lang := range locale.Languages() {
+		t.Run(lang, func(t *testing.T) {
+			w := ParseLang("11am - 10:30pm", lang)
+			want := "lun=~11:00 - 22:30 mar=~11:00 - 22:30 mié=~11:00 - 22:30 jue=~11:00 - 22:30 vie=~11:00 - 22:30 sáb=~11:00 - 22:30 dom=~11:00 - 22:30"
+			if got := weekString(w); got != want {
+				t.Errorf("got %s, want %s", got, want)
+			}
+		})
+	}
+}
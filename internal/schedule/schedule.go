@@ -0,0 +1,171 @@
+package schedule
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// MinutesPerDay es el número de minutos de un día
+const MinutesPerDay = 24 * 60
+
+// Interval representa un tramo de apertura en minutos desde medianoche.
+// Si el negocio cierra después de medianoche, Close es mayor que MinutesPerDay.
+type Interval struct {
+	Open  int
+	Close int
+}
+
+// Day representa el horario de un día de la semana
+type Day struct {
+	Known     bool       // Hay información para este día
+	Closed    bool       // Cerrado todo el día
+	AllDay    bool       // Abierto 24 horas
+	Assumed   bool       // Deducido de un horario sin día concreto
+	Intervals []Interval // Tramos de apertura
+}
+
+// Week es el horario semanal indexado por time.Weekday (0 = domingo)
+type Week struct {
+	Days [7]Day
+}
+
+// WeekOrder es el orden de presentación de los días, empezando en lunes
+var WeekOrder = []time.Weekday{
+	time.Monday, time.Tuesday, time.Wednesday, time.Thursday,
+	time.Friday, time.Saturday, time.Sunday,
+}
+
+// Day devuelve el horario del día indicado
+func (w *Week) Day(d time.Weekday) Day {
+	return w.Days[int(d)%7]
+}
+
+// KnownDays devuelve cuántos días tienen información
+func (w *Week) KnownDays() int {
+	count := 0
+	for _, d := range w.Days {
+		if d.Known {
+			count++
+		}
+	}
+	return count
+}
+
+// Hours devuelve los tramos del día ("10:00 - 14:00"), o "" si no hay
+// información, está cerrado o abre las 24 horas. Esos casos se nombran al
+// mostrarlos, en el idioma de la salida.
+func (d Day) Hours() string {
+	if !d.Known || d.Closed || d.AllDay {
+		return ""
+	}
+	return FormatIntervals(d.Intervals)
+}
+
+// String devuelve el tramo como "HH:MM - HH:MM"
+func (iv Interval) String() string {
+	return FormatMinutes(iv.Open) + " - " + FormatMinutes(iv.Close)
+}
+
+// FormatIntervals une varios tramos separados por comas. En jornada partida
+// los tramos se compactan ("10:00-14:00, 17:00-20:30") para que quepan en una línea.
+func FormatIntervals(intervals []Interval) string {
+	if len(intervals) == 1 {
+		return intervals[0].String()
+	}
+	parts := make([]string, 0, len(intervals))
+	for _, iv := range intervals {
+		parts = append(parts, FormatMinutes(iv.Open)+"-"+FormatMinutes(iv.Close))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// FormatMinutes convierte minutos desde medianoche a "HH:MM".
+// Los valores posteriores a medianoche se muestran como hora del día siguiente.
+func FormatMinutes(mins int) string {
+	if mins == MinutesPerDay {
+		return "24:00"
+	}
+	mins = mins % MinutesPerDay
+	return fmt.Sprintf("%02d:%02d", mins/60, mins%60)
+}
+
+// Span es un periodo de apertura con fecha y hora concretas
+type Span struct {
+	Start time.Time
+	End   time.Time
+}
+
+// Spans devuelve los periodos de apertura entre el día anterior a from y los
+// days días siguientes, ordenados y con los periodos contiguos unidos
+func (w *Week) Spans(from time.Time, days int) []Span {
+	var spans []Span
+
+	for offset := -1; offset <= days; offset++ {
+		date := time.Date(from.Year(), from.Month(), from.Day()+offset, 0, 0, 0, 0, from.Location())
+		day := w.Day(date.Weekday())
+
+		switch {
+		case !day.Known || day.Closed:
+			continue
+		case day.AllDay:
+			spans = appendSpan(spans, Span{Start: date, End: date.AddDate(0, 0, 1)})
+		default:
+			for _, iv := range day.Intervals {
+				spans = appendSpan(spans, Span{
+					Start: date.Add(time.Duration(iv.Open) * time.Minute),
+					End:   date.Add(time.Duration(iv.Close) * time.Minute),
+				})
+			}
+		}
+	}
+
+	return spans
+}
+
+// appendSpan añade un periodo uniéndolo con el anterior si se solapan
+func appendSpan(spans []Span, s Span) []Span {
+	if n := len(spans); n > 0 && !s.Start.After(spans[n-1].End) {
+		if s.End.After(spans[n-1].End) {
+			spans[n-1].End = s.End
+		}
+		return spans
+	}
+	return append(spans, s)
+}
+
+// Countdown describe el tiempo hasta el próximo cambio de estado.
+// Las duraciones a cero indican que no se conoce el cambio en la próxima semana.
+type Countdown struct {
+	Open      bool
+	ClosesIn  time.Duration // Abierto: tiempo hasta el cierre
+	OpensIn   time.Duration // Cerrado: tiempo hasta la próxima apertura
+	ClosedAgo time.Duration // Cerrado: tiempo desde el último cierre
+}
+
+// CountdownAt calcula el estado y los tiempos hasta el próximo cambio en t
+func (w *Week) CountdownAt(t time.Time) Countdown {
+	const horizon = 7
+
+	var c Countdown
+	limit := time.Date(t.Year(), t.Month(), t.Day()+horizon, 0, 0, 0, 0, t.Location())
+
+	for _, s := range w.Spans(t, horizon) {
+		switch {
+		case !s.End.After(t):
+			c.ClosedAgo = t.Sub(s.End)
+		case !s.Start.After(t):
+			c.Open = true
+			if s.End.Before(limit) {
+				c.ClosesIn = s.End.Sub(t)
+			}
+			c.ClosedAgo = 0
+			return c
+		default:
+			c.OpensIn = s.Start.Sub(t)
+			return c
+		}
+	}
+
+	return c
+}